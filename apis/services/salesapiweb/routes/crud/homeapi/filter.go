@@ -0,0 +1,21 @@
+package homeapi
+
+import (
+	"net/http"
+
+	"github.com/ardanlabs/service/app/core/crud/homeapp"
+)
+
+func parseQueryParams(r *http.Request) (homeapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	qp := homeapp.QueryParams{
+		Page:    values.Get("page"),
+		Rows:    values.Get("rows"),
+		ID:      values.Get("home_id"),
+		UserID:  values.Get("user_id"),
+		OrderBy: values.Get("orderBy"),
+	}
+
+	return qp, nil
+}
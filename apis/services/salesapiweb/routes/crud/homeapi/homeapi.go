@@ -0,0 +1,103 @@
+// Package homeapi maintains the web based api for home access.
+package homeapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/app/core/crud/homeapp"
+	"github.com/ardanlabs/service/foundation/web"
+	"github.com/google/uuid"
+)
+
+type api struct {
+	homeApp *homeapp.Core
+}
+
+func newAPI(homeApp *homeapp.Core) *api {
+	return &api{
+		homeApp: homeApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app homeapp.NewHome
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	hme, err := api.homeApp.Create(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, hme, http.StatusCreated)
+}
+
+func (api *api) update(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app homeapp.UpdateHome
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	hme, err := api.homeApp.Update(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, hme, http.StatusOK)
+}
+
+func (api *api) delete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := api.homeApp.Delete(ctx); err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, nil, http.StatusNoContent)
+}
+
+// deleteBulk removes the homes whose ids are given in the request body,
+// returning a per-id result array. Pass ?atomic=true to abort the whole
+// batch on the first failure instead of reporting partial success.
+func (api *api) deleteBulk(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var body struct {
+		IDs []uuid.UUID `json:"ids"`
+	}
+	if err := web.Decode(r, &body); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	atomic, _ := strconv.ParseBool(r.URL.Query().Get("atomic"))
+
+	results, err := api.homeApp.DeleteBulk(ctx, body.IDs, atomic)
+	if err != nil {
+		return err
+	}
+
+	return web.RespondMulti(ctx, w, results, atomic)
+}
+
+func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return err
+	}
+
+	hmes, err := api.homeApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, hmes, http.StatusOK)
+}
+
+func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	hme, err := api.homeApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, hme, http.StatusOK)
+}
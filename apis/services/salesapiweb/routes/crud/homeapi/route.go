@@ -0,0 +1,28 @@
+package homeapi
+
+import (
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/app/core/crud/homeapp"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	HomeApp *homeapp.Core
+}
+
+// Routes adds the home domain routes to the application.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	api := newAPI(cfg.HomeApp)
+
+	app.Handle(http.MethodGet, version, "/homes", api.query, mid.AuthorizeScope("homes.read"))
+	app.Handle(http.MethodGet, version, "/homes/{home_id}", api.queryByID, mid.AuthorizeScope("homes.read"))
+	app.Handle(http.MethodPost, version, "/homes", api.create, mid.AuthorizeScope("homes.write"))
+	app.Handle(http.MethodPut, version, "/homes/{home_id}", api.update, mid.AuthorizeScope("homes.write"))
+	app.Handle(http.MethodDelete, version, "/homes/{home_id}", api.delete, mid.AuthorizeScope("homes.write"))
+	app.Handle(http.MethodDelete, version, "/homes", api.deleteBulk, mid.AuthorizeScope("homes.write"))
+}
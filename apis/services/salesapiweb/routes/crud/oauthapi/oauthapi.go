@@ -0,0 +1,223 @@
+// Package oauthapi maintains the web based api for the OAuth2/OIDC
+// authorization server: /authorize, /token, and OIDC discovery.
+package oauthapi
+
+import (
+	"context"
+	_ "embed"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/app/core/crud/oauthapp"
+	"github.com/ardanlabs/service/foundation/validate"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+//go:embed pages/consent.html
+var consentPage string
+
+var consentTmpl = template.Must(template.New("consent").Parse(consentPage))
+
+type api struct {
+	oauthApp *oauthapp.Core
+	issuer   string
+}
+
+func newAPI(oauthApp *oauthapp.Core, issuer string) *api {
+	return &api{
+		oauthApp: oauthApp,
+		issuer:   issuer,
+	}
+}
+
+// authorize renders the consent page on GET. On POST it redirects back to
+// the client with `code` and `state` set if the resource owner's decision
+// was "allow", or with `error=access_denied` for anything else (including a
+// deliberate "deny"). client_id and redirect_uri are validated against the
+// registered client before any redirect is issued.
+func (api *api) authorize(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	req := oauthapp.AuthorizeRequest{
+		ResponseType:        r.URL.Query().Get("response_type"),
+		ClientID:            r.URL.Query().Get("client_id"),
+		RedirectURI:         r.URL.Query().Get("redirect_uri"),
+		Scope:               r.URL.Query().Get("scope"),
+		State:               r.URL.Query().Get("state"),
+		CodeChallenge:       r.URL.Query().Get("code_challenge"),
+		CodeChallengeMethod: r.URL.Query().Get("code_challenge_method"),
+	}
+
+	if err := validate.Check(req); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	// Every branch below ends in either rendering the consent page or
+	// redirecting the browser to req.RedirectURI, so client_id/redirect_uri
+	// must be validated against the registered client up front — a denied
+	// decision redirects just as much as an allowed one, and neither may
+	// send the browser to a URI the client never registered.
+	if _, err := api.oauthApp.ValidateAuthorize(ctx, req.ClientID, req.RedirectURI); err != nil {
+		return err
+	}
+
+	if r.Method == http.MethodGet {
+		data := struct {
+			ClientID string
+			Scope    string
+			State    string
+		}{
+			ClientID: req.ClientID,
+			Scope:    req.Scope,
+			State:    req.State,
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		return consentTmpl.Execute(w, data)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if r.PostForm.Get("decision") != "allow" {
+		redirectURL, err := buildRedirectURL(req.RedirectURI, map[string]string{
+			"error": "access_denied",
+			"state": req.State,
+		})
+		if err != nil {
+			return errs.New(errs.FailedPrecondition, err)
+		}
+
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+
+		return nil
+	}
+
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.Newf(errs.Unauthenticated, "authorize: consent requires an authenticated user: %s", err)
+	}
+
+	code, err := api.oauthApp.Authorize(ctx, userID, req)
+	if err != nil {
+		return err
+	}
+
+	redirectURL, err := buildRedirectURL(req.RedirectURI, map[string]string{
+		"code":  code,
+		"state": req.State,
+	})
+	if err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+
+	return nil
+}
+
+// buildRedirectURL merges params into redirectURI's existing query string
+// rather than concatenating, so a state value containing "&" or "=" can't
+// inject extra parameters and a client's redirect_uri that already carries
+// a query string (e.g. "https://client.example/cb?tenant=acme") keeps it.
+// Empty values in params are omitted.
+func buildRedirectURL(redirectURI string, params map[string]string) (string, error) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	for k, v := range params {
+		if v == "" {
+			continue
+		}
+		q.Set(k, v)
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (api *api) token(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	req := oauthapp.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		Scope:        r.PostForm.Get("scope"),
+	}
+
+	if err := validate.Check(req); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	tok, err := api.oauthApp.Token(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, tok, http.StatusOK)
+}
+
+func (api *api) createClient(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var app oauthapp.NewClient
+	if err := web.Decode(r, &app); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	client, err := api.oauthApp.CreateClient(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, client, http.StatusCreated)
+}
+
+// openIDConfiguration serves the OIDC discovery document every compliant
+// client and library looks for before talking to the authorization server.
+func (api *api) openIDConfiguration(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	doc := struct {
+		Issuer                string   `json:"issuer"`
+		AuthorizationEndpoint string   `json:"authorization_endpoint"`
+		TokenEndpoint         string   `json:"token_endpoint"`
+		JWKSURI               string   `json:"jwks_uri"`
+		ResponseTypes         []string `json:"response_types_supported"`
+		GrantTypes            []string `json:"grant_types_supported"`
+		ScopesSupported       []string `json:"scopes_supported"`
+		SubjectTypes          []string `json:"subject_types_supported"`
+		IDTokenSigningAlgs    []string `json:"id_token_signing_alg_values_supported"`
+	}{
+		Issuer:                api.issuer,
+		AuthorizationEndpoint: api.issuer + "/oauth/authorize",
+		TokenEndpoint:         api.issuer + "/oauth/token",
+		JWKSURI:               api.issuer + "/oauth/.well-known/jwks.json",
+		ResponseTypes:         []string{"code"},
+		GrantTypes:            []string{"authorization_code", "refresh_token", "client_credentials"},
+		ScopesSupported:       []string{"openid", "roles"},
+		SubjectTypes:          []string{"public"},
+		IDTokenSigningAlgs:    []string{"RS256"},
+	}
+
+	return web.Respond(ctx, w, doc, http.StatusOK)
+}
+
+// jwks serves the JSON Web Key Set relying parties fetch to verify this
+// server's RS256 signatures.
+func (api *api) jwks(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	keys, err := api.oauthApp.JWKS()
+	if err != nil {
+		return err
+	}
+
+	return web.Respond(ctx, w, map[string]any{"keys": keys}, http.StatusOK)
+}
@@ -0,0 +1,30 @@
+package oauthapi
+
+import (
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/app/core/crud/oauthapp"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	OAuthApp *oauthapp.Core
+	Issuer   string
+}
+
+// Routes adds the OAuth2/OIDC authorization server endpoints to the
+// application.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	api := newAPI(cfg.OAuthApp, cfg.Issuer)
+
+	app.Handle(http.MethodGet, version, "/oauth/authorize", api.authorize, mid.SkipAuthz("authorization entry point: validates the client and redirect_uri itself"))
+	app.Handle(http.MethodPost, version, "/oauth/authorize", api.authorize, mid.SkipAuthz("authorization entry point: validates the client and redirect_uri itself"))
+	app.Handle(http.MethodPost, version, "/oauth/token", api.token, mid.SkipAuthz("token issuance authenticates the client itself"))
+	app.Handle(http.MethodPost, version, "/oauth/clients", api.createClient, mid.RequireRole("ADMIN"))
+	app.Handle(http.MethodGet, version, "/oauth/.well-known/openid-configuration", api.openIDConfiguration, mid.SkipAuthz("public OIDC discovery document"))
+	app.Handle(http.MethodGet, version, "/oauth/.well-known/jwks.json", api.jwks, mid.SkipAuthz("public signing keys"))
+}
@@ -0,0 +1,31 @@
+package userapi
+
+import (
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/app/core/crud/userapp"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	UserApp              *userapp.Core
+	PasswordGrantEnabled bool
+}
+
+// Routes adds the user domain routes to the application.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	api := newAPI(cfg.UserApp, cfg.PasswordGrantEnabled)
+
+	app.Handle(http.MethodGet, version, "/users", api.query, mid.AuthorizeScope("users.read"))
+	app.Handle(http.MethodGet, version, "/users/{user_id}", api.queryByID, mid.AuthorizeScope("users.read"))
+	app.Handle(http.MethodPost, version, "/users", api.create, mid.AuthorizeScope("users.write"))
+	app.Handle(http.MethodPut, version, "/users/{user_id}", api.update, mid.AuthorizeScope("users.write"))
+	app.Handle(http.MethodPut, version, "/users/{user_id}/role", api.updateRole, mid.AuthorizeScope("users.write"))
+	app.Handle(http.MethodDelete, version, "/users/{user_id}", api.delete, mid.AuthorizeScope("users.write"))
+	app.Handle(http.MethodDelete, version, "/users", api.deleteBulk, mid.AuthorizeScope("users.write"))
+	app.Handle(http.MethodGet, version, "/users/token/{kid}", api.token, mid.SkipAuthz("legacy password grant: authenticates the caller itself via Basic auth, same as /oauth/token"))
+}
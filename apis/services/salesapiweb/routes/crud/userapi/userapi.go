@@ -6,20 +6,27 @@ import (
 	"errors"
 	"net/http"
 	"net/mail"
+	"strconv"
 
 	"github.com/ardanlabs/service/app/api/errs"
 	"github.com/ardanlabs/service/app/core/crud/userapp"
 	"github.com/ardanlabs/service/foundation/validate"
 	"github.com/ardanlabs/service/foundation/web"
+	"github.com/google/uuid"
 )
 
 type api struct {
 	userApp *userapp.Core
+	// passwordGrantEnabled guards the Basic-auth token handler below. Once
+	// first-party clients move to oauthapi's authorization_code + PKCE
+	// flow, operators can disable this path in production.
+	passwordGrantEnabled bool
 }
 
-func newAPI(userApp *userapp.Core) *api {
+func newAPI(userApp *userapp.Core, passwordGrantEnabled bool) *api {
 	return &api{
-		userApp: userApp,
+		userApp:              userApp,
+		passwordGrantEnabled: passwordGrantEnabled,
 	}
 }
 
@@ -73,6 +80,29 @@ func (api *api) delete(ctx context.Context, w http.ResponseWriter, r *http.Reque
 	return web.Respond(ctx, w, nil, http.StatusNoContent)
 }
 
+// deleteBulk removes the users whose ids are given in the request body,
+// returning a per-id result array. Pass ?cascade=true to also remove each
+// deleted user's homes, and ?atomic=true to abort the whole batch on the
+// first failure instead of reporting partial success.
+func (api *api) deleteBulk(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var body struct {
+		IDs []uuid.UUID `json:"ids"`
+	}
+	if err := web.Decode(r, &body); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	cascade, _ := strconv.ParseBool(r.URL.Query().Get("cascade"))
+	atomic, _ := strconv.ParseBool(r.URL.Query().Get("atomic"))
+
+	results, err := api.userApp.DeleteBulk(ctx, body.IDs, cascade, atomic)
+	if err != nil {
+		return err
+	}
+
+	return web.RespondMulti(ctx, w, results, atomic)
+}
+
 func (api *api) query(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	qp, err := parseQueryParams(r)
 	if err != nil {
@@ -97,6 +127,10 @@ func (api *api) queryByID(ctx context.Context, w http.ResponseWriter, r *http.Re
 }
 
 func (api *api) token(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if !api.passwordGrantEnabled {
+		return errs.Newf(errs.FailedPrecondition, "authorize: password grant is disabled, use /oauth/token with authorization_code instead")
+	}
+
 	kid := web.Param(r, "kid")
 	if kid == "" {
 		return validate.NewFieldsError("kid", errors.New("missing kid"))
@@ -0,0 +1,368 @@
+package adminapi
+
+import (
+	"context"
+	_ "embed"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/app/core/crud/oauthapp"
+	"github.com/ardanlabs/service/app/core/crud/userapp"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+//go:embed pages/users.html
+var usersPage string
+
+//go:embed pages/user_edit.html
+var userEditPage string
+
+//go:embed pages/apps.html
+var appsPage string
+
+//go:embed pages/app_edit.html
+var appEditPage string
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+var (
+	usersTmpl    = template.Must(template.New("users").Parse(usersPage))
+	userEditTmpl = template.Must(template.New("user_edit").Parse(userEditPage))
+	appsTmpl     = template.Must(template.New("apps").Funcs(templateFuncs).Parse(appsPage))
+	appEditTmpl  = template.Must(template.New("app_edit").Parse(appEditPage))
+)
+
+var csrf web.CSRF
+
+type api struct {
+	userApp  *userapp.Core
+	oauthApp *oauthapp.Core
+}
+
+func newAPI(userApp *userapp.Core, oauthApp *oauthapp.Core) *api {
+	return &api{
+		userApp:  userApp,
+		oauthApp: oauthApp,
+	}
+}
+
+// usersList renders the searchable, paginated user table along with the
+// inline create form.
+func (api *api) usersList(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	qp := parseUserSearch(r)
+
+	doc, err := api.userApp.Query(ctx, qp)
+	if err != nil {
+		return err
+	}
+
+	token, err := csrf.Issue(w)
+	if err != nil {
+		return errs.Newf(errs.Internal, "csrf: %s", err)
+	}
+
+	data := struct {
+		Users     []userapp.User
+		Total     int
+		Query     userapp.QueryParams
+		CSRFToken string
+	}{
+		Users:     doc.Items,
+		Total:     doc.Total,
+		Query:     qp,
+		CSRFToken: token,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return usersTmpl.Execute(w, data)
+}
+
+// usersCreate registers a new user from the admin form and redirects back
+// to the list.
+func (api *api) usersCreate(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if err := csrf.Validate(r); err != nil {
+		return errs.New(errs.PermissionDenied, err)
+	}
+
+	app := userapp.NewUser{
+		Name:            r.PostForm.Get("name"),
+		Email:           r.PostForm.Get("email"),
+		Roles:           []string{r.PostForm.Get("role")},
+		Password:        r.PostForm.Get("password"),
+		PasswordConfirm: r.PostForm.Get("password"),
+	}
+
+	if _, err := api.userApp.Create(ctx, app); err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/v1/admin/users", http.StatusSeeOther)
+
+	return nil
+}
+
+// usersEdit renders the edit form for the user named by {user_id}.
+func (api *api) usersEdit(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	usr, err := api.userApp.QueryByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	token, err := csrf.Issue(w)
+	if err != nil {
+		return errs.Newf(errs.Internal, "csrf: %s", err)
+	}
+
+	var role string
+	if len(usr.Roles) > 0 {
+		role = usr.Roles[0]
+	}
+
+	data := struct {
+		User      userapp.User
+		Role      string
+		CSRFToken string
+	}{
+		User:      usr,
+		Role:      role,
+		CSRFToken: token,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return userEditTmpl.Execute(w, data)
+}
+
+// usersUpdate applies profile and role changes from the edit form and
+// redirects back to the list.
+func (api *api) usersUpdate(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if err := csrf.Validate(r); err != nil {
+		return errs.New(errs.PermissionDenied, err)
+	}
+
+	name := r.PostForm.Get("name")
+	email := r.PostForm.Get("email")
+
+	if _, err := api.userApp.Update(ctx, userapp.UpdateUser{Name: &name, Email: &email}); err != nil {
+		return err
+	}
+
+	if role := r.PostForm.Get("role"); role != "" {
+		if _, err := api.userApp.UpdateRole(ctx, userapp.UpdateUserRole{Role: role}); err != nil {
+			return err
+		}
+	}
+
+	http.Redirect(w, r, "/v1/admin/users", http.StatusSeeOther)
+
+	return nil
+}
+
+// usersDelete removes the user named by {user_id} and redirects back to the
+// list.
+func (api *api) usersDelete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if err := csrf.Validate(r); err != nil {
+		return errs.New(errs.PermissionDenied, err)
+	}
+
+	if err := api.userApp.Delete(ctx); err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/v1/admin/users", http.StatusSeeOther)
+
+	return nil
+}
+
+// appsList renders the registered OAuth2 clients, optionally narrowed by the
+// ?q= client_id search box.
+func (api *api) appsList(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	clients, err := api.oauthApp.QueryClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	q := r.URL.Query().Get("q")
+	if q != "" {
+		filtered := clients[:0]
+		for _, client := range clients {
+			if strings.Contains(client.ClientID, q) {
+				filtered = append(filtered, client)
+			}
+		}
+		clients = filtered
+	}
+
+	token, err := csrf.Issue(w)
+	if err != nil {
+		return errs.Newf(errs.Internal, "csrf: %s", err)
+	}
+
+	data := struct {
+		Clients   []oauthapp.Client
+		Query     string
+		CSRFToken string
+	}{
+		Clients:   clients,
+		Query:     q,
+		CSRFToken: token,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return appsTmpl.Execute(w, data)
+}
+
+// appsCreate registers a new OAuth2 client from the admin form and redirects
+// back to the list.
+func (api *api) appsCreate(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if err := csrf.Validate(r); err != nil {
+		return errs.New(errs.PermissionDenied, err)
+	}
+
+	app := oauthapp.NewClient{
+		ClientID:      r.PostForm.Get("client_id"),
+		ClientSecret:  r.PostForm.Get("client_secret"),
+		RedirectURIs:  splitCSV(r.PostForm.Get("redirect_uris")),
+		AllowedScopes: splitCSV(r.PostForm.Get("allowed_scopes")),
+		GrantTypes:    splitCSV(r.PostForm.Get("grant_types")),
+	}
+
+	if _, err := api.oauthApp.CreateClient(ctx, app); err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/v1/admin/apps", http.StatusSeeOther)
+
+	return nil
+}
+
+// appsEdit renders the edit form for the client named by {client_id}.
+func (api *api) appsEdit(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	clientID := web.Param(r, "client_id")
+
+	client, err := api.oauthApp.QueryClientByClientID(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	token, err := csrf.Issue(w)
+	if err != nil {
+		return errs.Newf(errs.Internal, "csrf: %s", err)
+	}
+
+	data := struct {
+		Client        oauthapp.Client
+		RedirectURIs  string
+		AllowedScopes string
+		GrantTypes    string
+		CSRFToken     string
+	}{
+		Client:        client,
+		RedirectURIs:  strings.Join(client.RedirectURIs, ", "),
+		AllowedScopes: strings.Join(client.AllowedScopes, ", "),
+		GrantTypes:    strings.Join(client.GrantTypes, ", "),
+		CSRFToken:     token,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return appEditTmpl.Execute(w, data)
+}
+
+// appsUpdate applies redirect URI, scope, and grant type changes from the
+// edit form and redirects back to the list.
+func (api *api) appsUpdate(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if err := csrf.Validate(r); err != nil {
+		return errs.New(errs.PermissionDenied, err)
+	}
+
+	clientID := web.Param(r, "client_id")
+
+	var upd oauthapp.UpdateClient
+
+	if raw := r.PostForm.Get("redirect_uris"); raw != "" {
+		uris := splitCSV(raw)
+		upd.RedirectURIs = &uris
+	}
+
+	if raw := r.PostForm.Get("allowed_scopes"); raw != "" {
+		scopes := splitCSV(raw)
+		upd.AllowedScopes = &scopes
+	}
+
+	if raw := r.PostForm.Get("grant_types"); raw != "" {
+		grants := splitCSV(raw)
+		upd.GrantTypes = &grants
+	}
+
+	if _, err := api.oauthApp.UpdateClient(ctx, clientID, upd); err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/v1/admin/apps", http.StatusSeeOther)
+
+	return nil
+}
+
+// appsDelete removes the client named by {client_id} and redirects back to
+// the list.
+func (api *api) appsDelete(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if err := csrf.Validate(r); err != nil {
+		return errs.New(errs.PermissionDenied, err)
+	}
+
+	clientID := web.Param(r, "client_id")
+
+	if err := api.oauthApp.DeleteClient(ctx, clientID); err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, "/v1/admin/apps", http.StatusSeeOther)
+
+	return nil
+}
+
+// splitCSV splits a comma separated form field into a trimmed, non-empty
+// slice of values.
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,22 @@
+package adminapi
+
+import (
+	"net/http"
+
+	"github.com/ardanlabs/service/app/core/crud/userapp"
+)
+
+// parseUserSearch builds the same QueryParams shape userapi's query
+// endpoint accepts, so the admin search box and the JSON API stay behind
+// one filter.
+func parseUserSearch(r *http.Request) userapp.QueryParams {
+	values := r.URL.Query()
+
+	return userapp.QueryParams{
+		Page:    values.Get("page"),
+		Rows:    values.Get("rows"),
+		Email:   values.Get("email"),
+		Name:    values.Get("name"),
+		OrderBy: values.Get("orderBy"),
+	}
+}
@@ -0,0 +1,40 @@
+// Package adminapi maintains the server-rendered admin console for managing
+// users and the OAuth2 clients registered with oauthapi. Every route is
+// gated by mid.RequireRole("ADMIN") and every POST is guarded by a
+// web.CSRF token issued on the GET that rendered the form it came from.
+package adminapi
+
+import (
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/app/core/crud/oauthapp"
+	"github.com/ardanlabs/service/app/core/crud/userapp"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	UserApp  *userapp.Core
+	OAuthApp *oauthapp.Core
+}
+
+// Routes adds the admin console routes to the application.
+func Routes(app *web.App, cfg Config) {
+	const version = "v1"
+
+	api := newAPI(cfg.UserApp, cfg.OAuthApp)
+	admin := mid.RequireRole("ADMIN")
+
+	app.Handle(http.MethodGet, version, "/admin/users", api.usersList, admin)
+	app.Handle(http.MethodPost, version, "/admin/users", api.usersCreate, admin)
+	app.Handle(http.MethodGet, version, "/admin/users/{user_id}", api.usersEdit, admin)
+	app.Handle(http.MethodPost, version, "/admin/users/{user_id}", api.usersUpdate, admin)
+	app.Handle(http.MethodPost, version, "/admin/users/{user_id}/delete", api.usersDelete, admin)
+
+	app.Handle(http.MethodGet, version, "/admin/apps", api.appsList, admin)
+	app.Handle(http.MethodPost, version, "/admin/apps", api.appsCreate, admin)
+	app.Handle(http.MethodGet, version, "/admin/apps/{client_id}", api.appsEdit, admin)
+	app.Handle(http.MethodPost, version, "/admin/apps/{client_id}", api.appsUpdate, admin)
+	app.Handle(http.MethodPost, version, "/admin/apps/{client_id}/delete", api.appsDelete, admin)
+}
@@ -0,0 +1,51 @@
+package mid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/foundation/scope"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+// AuthorizeScope constructs a middleware that requires the authenticated
+// principal's JWT claims to carry a scope satisfying required, using
+// hierarchical scope matching (see foundation/scope). The scope is recorded
+// in the package-level registry at bind time so RegisteredScopes can list
+// every scope a mounted route depends on.
+func AuthorizeScope(required string) web.MidFunc {
+	s := scope.Scope(required)
+	scope.Register(s)
+
+	matcher := scope.Compile(s)
+
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			if rec, ok := recorderFromContext(ctx); ok {
+				rec.MarkConsulted()
+			}
+
+			claims, err := GetClaims(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			if !matcher.Allows(claims.Scopes) {
+				return errs.Newf(errs.PermissionDenied, "authorize: scope[%s] not granted", required)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// RegisteredScopes returns every scope referenced by any mounted route, for
+// documentation and consent-screen rendering.
+func RegisteredScopes() []scope.Scope {
+	return scope.Registered()
+}
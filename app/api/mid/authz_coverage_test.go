@@ -0,0 +1,131 @@
+package mid_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/app/api/mid/authzcoverage"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+func ok(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func panics(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	panic("handler reached a dependency that wasn't wired up")
+}
+
+// TestCheck_FlagsUnguardedRoute is the harness the chunk0-3 request asked
+// for: it binds a guarded, a skip-marked, and an unguarded route on a real
+// web.App and asserts authzcoverage.Check reports a violation for exactly
+// the unguarded one. A CI job that runs this test (wired to the real
+// application's route tables) is what turns an unguarded route into a build
+// failure instead of a silent gap.
+func TestCheck_FlagsUnguardedRoute(t *testing.T) {
+	var app web.App
+
+	app.Handle(http.MethodGet, "v1", "/guarded", ok, mid.AuthorizeScope("test.read"))
+	app.Handle(http.MethodGet, "v1", "/skipped", ok, mid.SkipAuthz("test route, intentionally public"))
+	app.Handle(http.MethodGet, "v1", "/unguarded", ok)
+
+	violations := authzcoverage.Check(context.Background(), &app)
+
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1: %+v", len(violations), violations)
+	}
+
+	if violations[0].Path != "/unguarded" {
+		t.Fatalf("violation for path %q, want /unguarded", violations[0].Path)
+	}
+}
+
+// TestCheck_FlagsAcrossMultipleMountedGroups mounts several route groups on
+// one shared web.App, each bound the same way a domain's own Routes(app,
+// cfg) function binds its group: one AuthorizeScope-gated CRUD-shaped group
+// (mirrors homeapi/userapi), one RequireRole-gated group (mirrors adminapi),
+// one SkipAuthz-marked public group (mirrors oauthapi's authorize/token
+// endpoints), and one route a reviewer forgot to gate. This is as close as
+// this checkout can get to exercising "the actual mounted web.App": the
+// real reporting.Routes/userapi.Routes/homeapi.Routes/oauthapi.Routes/
+// adminapi.Routes can't be called from a test here because their import
+// graphs reach app/api/mux, business/core/crud/user, and
+// business/core/crud/homebus, none of which exist in this source checkout
+// (see chunk0-4's commit history for the same gap). Once those packages
+// land, this test's three bindRoutes calls should be replaced with the real
+// Routes(app, cfg) calls they stand in for.
+func TestCheck_FlagsAcrossMultipleMountedGroups(t *testing.T) {
+	var app web.App
+
+	bindCRUDLikeRoutes(&app, "homes")
+	bindAdminLikeRoutes(&app)
+	bindOAuthLikeRoutes(&app)
+
+	app.Handle(http.MethodDelete, "v1", "/homes/{home_id}", ok)
+
+	violations := authzcoverage.Check(context.Background(), &app)
+
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1: %+v", len(violations), violations)
+	}
+
+	if violations[0].Path != "/homes/{home_id}" {
+		t.Fatalf("violation for path %q, want /homes/{home_id}", violations[0].Path)
+	}
+}
+
+// TestCheck_FlagsUnguardedRouteThatPanics covers a route that's both
+// unguarded and wired to a dependency that wasn't ready (a nil core
+// dereferenced deep in the handler, typically) - Check must report it as a
+// violation rather than letting the panic take the whole run down with it.
+func TestCheck_FlagsUnguardedRouteThatPanics(t *testing.T) {
+	var app web.App
+
+	app.Handle(http.MethodGet, "v1", "/broken", panics)
+
+	violations := authzcoverage.Check(context.Background(), &app)
+
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations, want 1: %+v", len(violations), violations)
+	}
+
+	if violations[0].Path != "/broken" {
+		t.Fatalf("violation for path %q, want /broken", violations[0].Path)
+	}
+}
+
+// bindCRUDLikeRoutes mirrors the shape homeapi.Routes/userapi.Routes bind:
+// a read scope on the collection and item endpoints, a write scope on
+// create/update/delete.
+func bindCRUDLikeRoutes(app *web.App, resource string) {
+	const version = "v1"
+
+	app.Handle(http.MethodGet, version, "/"+resource, ok, mid.AuthorizeScope(resource+".read"))
+	app.Handle(http.MethodGet, version, "/"+resource+"/{id}", ok, mid.AuthorizeScope(resource+".read"))
+	app.Handle(http.MethodPost, version, "/"+resource, ok, mid.AuthorizeScope(resource+".write"))
+	app.Handle(http.MethodPut, version, "/"+resource+"/{id}", ok, mid.AuthorizeScope(resource+".write"))
+}
+
+// bindAdminLikeRoutes mirrors adminapi.Routes: every route behind a single
+// RequireRole("ADMIN").
+func bindAdminLikeRoutes(app *web.App) {
+	const version = "v1"
+
+	admin := mid.RequireRole("ADMIN")
+
+	app.Handle(http.MethodGet, version, "/admin/users", ok, admin)
+	app.Handle(http.MethodPost, version, "/admin/users", ok, admin)
+}
+
+// bindOAuthLikeRoutes mirrors oauthapi.Routes: the authorize/token/jwks
+// endpoints are the authentication entry points themselves, so they're
+// explicitly marked as public rather than left silently unguarded.
+func bindOAuthLikeRoutes(app *web.App) {
+	const version = "v1"
+
+	app.Handle(http.MethodPost, version, "/oauth/token", ok, mid.SkipAuthz("token issuance authenticates the client/resource owner itself"))
+	app.Handle(http.MethodGet, version, "/oauth/.well-known/jwks.json", ok, mid.SkipAuthz("public signing keys"))
+}
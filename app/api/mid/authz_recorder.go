@@ -0,0 +1,59 @@
+package mid
+
+import (
+	"context"
+	"sync"
+)
+
+type authRecorderKey struct{}
+
+// AuthRecorder is injected into a request's context by the authorization
+// coverage check (see authzcoverage) so it can observe, after the handler
+// returns, whether any authorization middleware in the chain actually ran.
+// Production requests never carry one; recorderFromContext simply reports
+// absent and the authorization middleware skips its recorder calls.
+type AuthRecorder struct {
+	mu        sync.Mutex
+	consulted bool
+	skipped   bool
+	reason    string
+}
+
+// MarkConsulted records that an authorization middleware (Authorize,
+// AuthorizeScope, ...) evaluated a rule for this request.
+func (r *AuthRecorder) MarkConsulted() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.consulted = true
+}
+
+// MarkSkipped records that this route explicitly opted out of the coverage
+// check via SkipAuthz, along with the reason given.
+func (r *AuthRecorder) MarkSkipped(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.skipped = true
+	r.reason = reason
+}
+
+// Result reports whether an authorization middleware was consulted, and, if
+// the route opted out instead, the reason it gave.
+func (r *AuthRecorder) Result() (consulted, skipped bool, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.consulted, r.skipped, r.reason
+}
+
+// WithAuthRecorder returns a context carrying rec, for the coverage check to
+// attach to a synthetic request before driving a route's handler chain.
+func WithAuthRecorder(ctx context.Context, rec *AuthRecorder) context.Context {
+	return context.WithValue(ctx, authRecorderKey{}, rec)
+}
+
+func recorderFromContext(ctx context.Context) (*AuthRecorder, bool) {
+	rec, ok := ctx.Value(authRecorderKey{}).(*AuthRecorder)
+	return rec, ok
+}
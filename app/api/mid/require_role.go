@@ -0,0 +1,41 @@
+package mid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+// RequireRole constructs a middleware that requires the authenticated
+// principal's claims to carry role, gating the server-rendered admin routes
+// the same way AuthorizeScope gates the JSON API. Unlike scopes, roles
+// aren't hierarchical and aren't tracked in a registry: a route either
+// requires one or it doesn't.
+func RequireRole(role string) web.MidFunc {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			if rec, ok := recorderFromContext(ctx); ok {
+				rec.MarkConsulted()
+			}
+
+			claims, err := GetClaims(ctx)
+			if err != nil {
+				return errs.New(errs.Unauthenticated, err)
+			}
+
+			for _, have := range claims.Roles {
+				if have == role {
+					return handler(ctx, w, r)
+				}
+			}
+
+			return errs.Newf(errs.PermissionDenied, "authorize: role[%s] not granted", role)
+		}
+
+		return h
+	}
+
+	return m
+}
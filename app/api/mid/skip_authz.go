@@ -0,0 +1,31 @@
+package mid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ardanlabs/service/foundation/web"
+)
+
+// SkipAuthz marks a route as an explicit, visible opt-out from the
+// authorization coverage check (see authzcoverage). reason is recorded on
+// the request's AuthRecorder and stays visible where the route is bound, so
+// a route such as a liveness probe is allow-listed on purpose rather than
+// silently passing because no one wired up a rule. authzcoverage.Check
+// leaves skipped routes out of its violations and does not report their
+// reasons.
+func SkipAuthz(reason string) web.MidFunc {
+	m := func(handler web.Handler) web.Handler {
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			if rec, ok := recorderFromContext(ctx); ok {
+				rec.MarkSkipped(reason)
+			}
+
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
@@ -0,0 +1,18 @@
+package homeapp
+
+import "context"
+
+// AuditEvent describes a single row-level mutation for the audit log.
+type AuditEvent struct {
+	Action     string
+	Resource   string
+	ResourceID string
+	ActorID    string
+}
+
+// AuditLogger records a single audit event. DeleteBulk emits one per home it
+// actually deletes, so a partial bulk delete still leaves a trail naming
+// exactly the rows that changed.
+type AuditLogger interface {
+	Record(ctx context.Context, event AuditEvent) error
+}
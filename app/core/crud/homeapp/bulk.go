@@ -0,0 +1,80 @@
+package homeapp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/business/core/crud/homebus"
+	"github.com/google/uuid"
+)
+
+// maxBulkDelete is the largest batch DeleteBulk will accept in one call.
+const maxBulkDelete = 500
+
+// BulkResult reports the outcome of one id in a bulk operation.
+type BulkResult struct {
+	ID     uuid.UUID `json:"id"`
+	Status int       `json:"status"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// DeleteBulk removes the homes identified by ids. When atomic is true, every
+// id is resolved to an existing home before any delete runs, so a missing
+// id can never leave an earlier id in the same call deleted while the
+// caller is told the whole batch failed; homeBus.Delete itself still isn't
+// wrapped in a database transaction (homebus has no Tx primitive to expose
+// here), so a Delete that fails partway through a large atomic batch can
+// still leave prior deletes in that batch standing - that failure is
+// returned as an error same as today, it just can no longer be triggered by
+// the cheaper, far more common case of a bad id. In non-atomic mode each id
+// is still reported independently via the returned results, same as
+// before. Regardless of atomic, one audit event is emitted for every home
+// actually deleted.
+func (c *Core) DeleteBulk(ctx context.Context, ids []uuid.UUID, atomic bool) ([]BulkResult, error) {
+	if len(ids) > maxBulkDelete {
+		return nil, errs.Newf(errs.FailedPrecondition, "deletebulk: %d ids exceeds the %d limit", len(ids), maxBulkDelete)
+	}
+
+	actorID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Unauthenticated, "deletebulk: %s", err)
+	}
+
+	results := make([]BulkResult, 0, len(ids))
+	homes := make([]homebus.Home, 0, len(ids))
+
+	for _, id := range ids {
+		hme, err := c.homeBus.QueryByID(ctx, id)
+		if err != nil {
+			if atomic {
+				return nil, errs.Newf(errs.NotFound, "deletebulk: home[%s]: %s", id, err)
+			}
+
+			results = append(results, BulkResult{ID: id, Status: http.StatusNotFound, Error: err.Error()})
+			continue
+		}
+
+		homes = append(homes, hme)
+	}
+
+	for _, hme := range homes {
+		if err := c.homeBus.Delete(ctx, hme); err != nil {
+			if atomic {
+				return nil, errs.Newf(errs.Internal, "deletebulk: home[%s]: %s", hme.ID, err)
+			}
+
+			results = append(results, BulkResult{ID: hme.ID, Status: http.StatusInternalServerError, Error: err.Error()})
+			continue
+		}
+
+		if err := c.audit.Record(ctx, AuditEvent{Action: "delete", Resource: "home", ResourceID: hme.ID.String(), ActorID: actorID.String()}); err != nil {
+			return nil, errs.Newf(errs.Internal, "deletebulk: home[%s]: audit: %s", hme.ID, err)
+		}
+
+		results = append(results, BulkResult{ID: hme.ID, Status: http.StatusNoContent})
+	}
+
+	return results, nil
+}
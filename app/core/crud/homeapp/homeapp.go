@@ -13,12 +13,14 @@ import (
 // Core manages the set of app layer api functions for the home domain.
 type Core struct {
 	homeBus *homebus.Core
+	audit   AuditLogger
 }
 
 // NewCore constructs a home core API for use.
-func NewCore(homeBus *homebus.Core) *Core {
+func NewCore(homeBus *homebus.Core, audit AuditLogger) *Core {
 	return &Core{
 		homeBus: homeBus,
+		audit:   audit,
 	}
 }
 
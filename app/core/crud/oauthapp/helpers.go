@@ -0,0 +1,71 @@
+package oauthapp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func splitScope(scope string) []string {
+	if scope == "" {
+		return nil
+	}
+
+	return strings.Fields(scope)
+}
+
+func joinScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+func hasScope(scopes []string, want string) bool {
+	for _, s := range scopes {
+		if s == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// intersectScopes narrows the scopes a client requested down to the ones it
+// is actually allowed, so a misconfigured or malicious request can never
+// escalate beyond what was provisioned for the client.
+func intersectScopes(requested, allowed []string) []string {
+	if len(requested) == 0 {
+		return allowed
+	}
+
+	allowedSet := make(map[string]struct{}, len(allowed))
+	for _, a := range allowed {
+		allowedSet[a] = struct{}{}
+	}
+
+	var out []string
+	for _, r := range requested {
+		if _, ok := allowedSet[r]; ok {
+			out = append(out, r)
+		}
+	}
+
+	return out
+}
+
+// userRoles looks up the roles for the user an authorization code or
+// refresh token was issued to, so the id_token can embed them when the
+// caller requested the "roles" scope. Lookup failures degrade to no roles
+// rather than failing the token exchange.
+func (c *Core) userRoles(ctx context.Context, userID uuid.UUID) []string {
+	usr, err := c.userBus.QueryByID(ctx, userID)
+	if err != nil {
+		return nil
+	}
+
+	roles := make([]string, len(usr.Roles))
+	for i, r := range usr.Roles {
+		roles[i] = r.String()
+	}
+
+	return roles
+}
@@ -0,0 +1,98 @@
+package oauthapp
+
+import "github.com/ardanlabs/service/business/core/crud/oauthbus"
+
+// AuthorizeRequest represents the query parameters accepted by the
+// /authorize endpoint.
+type AuthorizeRequest struct {
+	ResponseType        string `validate:"required,eq=code"`
+	ClientID            string `validate:"required"`
+	RedirectURI         string `validate:"required"`
+	Scope               string
+	State               string
+	CodeChallenge       string `validate:"required"`
+	CodeChallengeMethod string `validate:"required,eq=S256"`
+}
+
+// TokenRequest represents the form body accepted by the /token endpoint. The
+// fields that apply depend on GrantType: authorization_code requires Code,
+// RedirectURI and CodeVerifier; refresh_token requires RefreshToken;
+// client_credentials validates only the client. A password grant request is
+// rejected by Core.Token (that grant is served by userapi's token handler),
+// so Username and Password are not read.
+type TokenRequest struct {
+	GrantType    string `validate:"required,oneof=authorization_code refresh_token client_credentials password"`
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	ClientID     string `validate:"required"`
+	ClientSecret string
+	Scope        string
+	Username     string
+	Password     string
+}
+
+// JWK is a single public signing key in JSON Web Key Set format, carrying
+// enough of the RSA public key (N, E) for a relying party to actually
+// verify an RS256 token signature, not just look up which key signed it.
+type JWK struct {
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// TokenResponse is the standard OAuth2 token response.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// Client is the app layer representation of a registered OAuth2 client,
+// returned from the client management endpoints. The secret is never
+// serialized back out.
+type Client struct {
+	ClientID      string   `json:"client_id"`
+	RedirectURIs  []string `json:"redirect_uris"`
+	AllowedScopes []string `json:"allowed_scopes"`
+	GrantTypes    []string `json:"grant_types"`
+}
+
+func toAppClient(bus oauthbus.Client) Client {
+	grants := make([]string, len(bus.GrantTypes))
+	for i, g := range bus.GrantTypes {
+		grants[i] = string(g)
+	}
+
+	return Client{
+		ClientID:      bus.ClientID,
+		RedirectURIs:  bus.RedirectURIs,
+		AllowedScopes: bus.AllowedScopes,
+		GrantTypes:    grants,
+	}
+}
+
+// UpdateClient contains the information that can be changed on a registered
+// OAuth2 client. A nil field leaves that client attribute untouched.
+type UpdateClient struct {
+	RedirectURIs  *[]string `json:"redirect_uris"`
+	AllowedScopes *[]string `json:"allowed_scopes"`
+	GrantTypes    *[]string `json:"grant_types"`
+}
+
+// NewClient contains the information needed to register a new OAuth2
+// client.
+type NewClient struct {
+	ClientID      string   `json:"client_id" validate:"required"`
+	ClientSecret  string   `json:"client_secret" validate:"required"`
+	RedirectURIs  []string `json:"redirect_uris" validate:"required,min=1"`
+	AllowedScopes []string `json:"allowed_scopes" validate:"required,min=1"`
+	GrantTypes    []string `json:"grant_types" validate:"required,min=1"`
+}
@@ -0,0 +1,436 @@
+// Package oauthapp maintains the app layer api for the OAuth2/OIDC
+// authorization server: authorization codes with PKCE, refresh token
+// rotation, and client credentials. Password grant requests are rejected
+// here; that grant is served by userapi's token handler.
+package oauthapp
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"math/big"
+	"time"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	"github.com/ardanlabs/service/business/core/crud/user"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Default lifetimes for issued tokens. These mirror the values most OIDC
+// providers use for first-party clients.
+const (
+	authCodeTTL     = 2 * time.Minute
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// TokenClaims is the set of claims an Issuer is asked to sign into a JWT. The
+// Roles field is only populated, and only honored by the issuer, when the
+// "roles" scope was granted.
+type TokenClaims struct {
+	Subject string
+	Scopes  []string
+	Roles   []string
+	Expires time.Time
+}
+
+// Issuer signs access and ID tokens using the service's existing KID-based
+// keys, and exposes the public half for the JWKS endpoint. It is satisfied
+// by the same signer userapp.Core uses for the password grant today.
+type Issuer interface {
+	Issue(ctx context.Context, kid string, claims TokenClaims) (string, error)
+	KeyIDs() []string
+	PublicKey(kid string) (*rsa.PublicKey, error)
+}
+
+// Core manages the set of app layer api functions for the OAuth2/OIDC
+// authorization server.
+type Core struct {
+	oauthBus *oauthbus.Core
+	userBus  *user.Core
+	issuer   Issuer
+	kid      string
+	// passwordGrantEnabled guards the legacy Basic-auth style password
+	// grant so operators can turn it off in production once first-party
+	// clients have migrated to authorization_code + PKCE.
+	passwordGrantEnabled bool
+}
+
+// NewCore constructs an oauth core API for use.
+func NewCore(oauthBus *oauthbus.Core, userBus *user.Core, issuer Issuer, kid string, passwordGrantEnabled bool) *Core {
+	return &Core{
+		oauthBus:             oauthBus,
+		userBus:              userBus,
+		issuer:               issuer,
+		kid:                  kid,
+		passwordGrantEnabled: passwordGrantEnabled,
+	}
+}
+
+// KeyIDs returns the set of key IDs the issuer currently signs with, for
+// publishing on the JWKS endpoint.
+func (c *Core) KeyIDs() []string {
+	return c.issuer.KeyIDs()
+}
+
+// JWKS returns every signing key as a JSON Web Key, including the RSA
+// modulus and exponent a relying party needs to actually verify a token's
+// RS256 signature, not just match it to a kid.
+func (c *Core) JWKS() ([]JWK, error) {
+	kids := c.issuer.KeyIDs()
+
+	keys := make([]JWK, 0, len(kids))
+	for _, kid := range kids {
+		pub, err := c.issuer.PublicKey(kid)
+		if err != nil {
+			return nil, errs.Newf(errs.Internal, "jwks: kid[%s]: %s", kid, err)
+		}
+
+		keys = append(keys, JWK{
+			Kid: kid,
+			Use: "sig",
+			Kty: "RSA",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		})
+	}
+
+	return keys, nil
+}
+
+// CreateClient registers a new OAuth2 client.
+func (c *Core) CreateClient(ctx context.Context, app NewClient) (Client, error) {
+	grants := make([]oauthbus.GrantType, len(app.GrantTypes))
+	for i, g := range app.GrantTypes {
+		grants[i] = oauthbus.GrantType(g)
+	}
+
+	secretHash, err := hashSecret(app.ClientSecret)
+	if err != nil {
+		return Client{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	nc := oauthbus.NewClient{
+		ClientID:      app.ClientID,
+		ClientSecret:  secretHash,
+		RedirectURIs:  app.RedirectURIs,
+		AllowedScopes: app.AllowedScopes,
+		GrantTypes:    grants,
+	}
+
+	client, err := c.oauthBus.CreateClient(ctx, nc)
+	if err != nil {
+		return Client{}, errs.Newf(errs.Internal, "create: client[%+v]: %s", app, err)
+	}
+
+	return toAppClient(client), nil
+}
+
+// QueryClients returns every registered OAuth2 client.
+func (c *Core) QueryClients(ctx context.Context) ([]Client, error) {
+	clients, err := c.oauthBus.QueryClients(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	apps := make([]Client, len(clients))
+	for i, client := range clients {
+		apps[i] = toAppClient(client)
+	}
+
+	return apps, nil
+}
+
+// QueryClientByClientID finds a single registered client by its public
+// client_id.
+func (c *Core) QueryClientByClientID(ctx context.Context, clientID string) (Client, error) {
+	client, err := c.oauthBus.QueryClientByClientID(ctx, clientID)
+	if err != nil {
+		return Client{}, errs.Newf(errs.NotFound, "query: clientID[%s]: %s", clientID, err)
+	}
+
+	return toAppClient(client), nil
+}
+
+// UpdateClient modifies a registered client's redirect URIs, allowed scopes,
+// or grant types.
+func (c *Core) UpdateClient(ctx context.Context, clientID string, app UpdateClient) (Client, error) {
+	client, err := c.oauthBus.QueryClientByClientID(ctx, clientID)
+	if err != nil {
+		return Client{}, errs.Newf(errs.NotFound, "update: clientID[%s]: %s", clientID, err)
+	}
+
+	uc := oauthbus.UpdateClient{
+		RedirectURIs:  app.RedirectURIs,
+		AllowedScopes: app.AllowedScopes,
+	}
+
+	if app.GrantTypes != nil {
+		grants := make([]oauthbus.GrantType, len(*app.GrantTypes))
+		for i, g := range *app.GrantTypes {
+			grants[i] = oauthbus.GrantType(g)
+		}
+		uc.GrantTypes = &grants
+	}
+
+	updClient, err := c.oauthBus.UpdateClient(ctx, client, uc)
+	if err != nil {
+		return Client{}, errs.Newf(errs.Internal, "update: clientID[%s]: %s", clientID, err)
+	}
+
+	return toAppClient(updClient), nil
+}
+
+// DeleteClient removes a registered client from the system.
+func (c *Core) DeleteClient(ctx context.Context, clientID string) error {
+	client, err := c.oauthBus.QueryClientByClientID(ctx, clientID)
+	if err != nil {
+		return errs.Newf(errs.NotFound, "delete: clientID[%s]: %s", clientID, err)
+	}
+
+	if err := c.oauthBus.DeleteClient(ctx, client); err != nil {
+		return errs.Newf(errs.Internal, "delete: clientID[%s]: %s", clientID, err)
+	}
+
+	return nil
+}
+
+// ValidateAuthorize checks that client_id is registered and that
+// redirect_uri is one of its registered redirect URIs. The caller must run
+// this before issuing *any* redirect back to redirect_uri from /authorize —
+// including a denied consent — so the endpoint can never be turned into an
+// open redirect by an unregistered client_id or a redirect_uri that was
+// never allow-listed for it.
+func (c *Core) ValidateAuthorize(ctx context.Context, clientID, redirectURI string) (oauthbus.Client, error) {
+	client, err := c.oauthBus.QueryClientByClientID(ctx, clientID)
+	if err != nil {
+		return oauthbus.Client{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	if !client.RedirectAllowed(redirectURI) {
+		return oauthbus.Client{}, errs.Newf(errs.InvalidArgument, "authorize: redirect_uri[%s] is not registered for client[%s]", redirectURI, clientID)
+	}
+
+	return client, nil
+}
+
+// Authorize validates an /authorize request against the registered client
+// and issues a short-lived authorization code bound to the presenting
+// user and the supplied PKCE code challenge.
+func (c *Core) Authorize(ctx context.Context, userID uuid.UUID, req AuthorizeRequest) (string, error) {
+	client, err := c.ValidateAuthorize(ctx, req.ClientID, req.RedirectURI)
+	if err != nil {
+		return "", err
+	}
+
+	if !client.GrantSupports(oauthbus.GrantAuthorizationCode) {
+		return "", errs.Newf(errs.InvalidArgument, "authorize: client[%s] is not allowed the authorization_code grant", req.ClientID)
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return "", errs.Newf(errs.Internal, "authorize: generate code: %s", err)
+	}
+
+	ar := oauthbus.AuthRequest{
+		Code:                code,
+		ClientID:            req.ClientID,
+		UserID:              userID,
+		RedirectURI:         req.RedirectURI,
+		Scopes:              intersectScopes(splitScope(req.Scope), client.AllowedScopes),
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Expires:             time.Now().Add(authCodeTTL),
+	}
+
+	if err := c.oauthBus.IssueAuthRequest(ctx, ar); err != nil {
+		return "", errs.Newf(errs.Internal, "authorize: issue code: %s", err)
+	}
+
+	return code, nil
+}
+
+// Token exchanges a grant for an access token, dispatching on GrantType.
+func (c *Core) Token(ctx context.Context, req TokenRequest) (TokenResponse, error) {
+	client, err := c.oauthBus.QueryClientByClientID(ctx, req.ClientID)
+	if err != nil {
+		return TokenResponse{}, errs.New(errs.Unauthenticated, err)
+	}
+
+	if !client.GrantSupports(oauthbus.GrantType(req.GrantType)) {
+		return TokenResponse{}, errs.Newf(errs.InvalidArgument, "token: client[%s] is not allowed the %s grant", req.ClientID, req.GrantType)
+	}
+
+	switch oauthbus.GrantType(req.GrantType) {
+	case oauthbus.GrantAuthorizationCode:
+		return c.tokenFromAuthCode(ctx, client, req)
+
+	case oauthbus.GrantRefreshToken:
+		return c.tokenFromRefreshToken(ctx, client, req)
+
+	case oauthbus.GrantClientCredentials:
+		return c.tokenFromClientCredentials(ctx, client, req)
+
+	case oauthbus.GrantPassword:
+		if !c.passwordGrantEnabled {
+			return TokenResponse{}, errs.Newf(errs.FailedPrecondition, "token: password grant is disabled")
+		}
+
+		return TokenResponse{}, errs.Newf(errs.Unimplemented, "token: password grant must be exchanged through userapi.token")
+
+	default:
+		return TokenResponse{}, errs.Newf(errs.InvalidArgument, "token: unsupported grant_type[%s]", req.GrantType)
+	}
+}
+
+func (c *Core) tokenFromAuthCode(ctx context.Context, client oauthbus.Client, req TokenRequest) (TokenResponse, error) {
+	if !verifySecret(client.ClientSecret, req.ClientSecret) {
+		return TokenResponse{}, errs.Newf(errs.Unauthenticated, "token: invalid client_secret")
+	}
+
+	ar, err := c.oauthBus.RedeemAuthRequest(ctx, req.Code)
+	if err != nil {
+		return TokenResponse{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	if ar.ClientID != client.ClientID || ar.RedirectURI != req.RedirectURI {
+		return TokenResponse{}, errs.Newf(errs.InvalidArgument, "token: code was not issued to client[%s] for redirect_uri[%s]", client.ClientID, req.RedirectURI)
+	}
+
+	if !verifyPKCE(ar.CodeChallenge, req.CodeVerifier) {
+		return TokenResponse{}, errs.Newf(errs.InvalidArgument, "token: code_verifier does not match code_challenge")
+	}
+
+	return c.issueTokenPair(ctx, client, ar.UserID, ar.Scopes)
+}
+
+func (c *Core) tokenFromRefreshToken(ctx context.Context, client oauthbus.Client, req TokenRequest) (TokenResponse, error) {
+	if !verifySecret(client.ClientSecret, req.ClientSecret) {
+		return TokenResponse{}, errs.Newf(errs.Unauthenticated, "token: invalid client_secret")
+	}
+
+	rt, err := c.oauthBus.RotateRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return TokenResponse{}, errs.New(errs.InvalidArgument, err)
+	}
+
+	if rt.ClientID != client.ClientID {
+		return TokenResponse{}, errs.Newf(errs.InvalidArgument, "token: refresh token was not issued to client[%s]", client.ClientID)
+	}
+
+	return c.issueTokenPair(ctx, client, rt.UserID, rt.Scopes)
+}
+
+func (c *Core) tokenFromClientCredentials(ctx context.Context, client oauthbus.Client, req TokenRequest) (TokenResponse, error) {
+	if !verifySecret(client.ClientSecret, req.ClientSecret) {
+		return TokenResponse{}, errs.Newf(errs.Unauthenticated, "token: invalid client_secret")
+	}
+
+	scopes := intersectScopes(splitScope(req.Scope), client.AllowedScopes)
+
+	claims := TokenClaims{
+		Subject: client.ClientID,
+		Scopes:  scopes,
+		Expires: time.Now().Add(accessTokenTTL),
+	}
+
+	access, err := c.issuer.Issue(ctx, c.kid, claims)
+	if err != nil {
+		return TokenResponse{}, errs.Newf(errs.Internal, "token: sign access token: %s", err)
+	}
+
+	return TokenResponse{
+		AccessToken: access,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(accessTokenTTL.Seconds()),
+		Scope:       joinScope(scopes),
+	}, nil
+}
+
+// issueTokenPair signs an access token plus an id_token (when the "roles"
+// scope was granted, the user's roles are embedded) and rotates in a new
+// refresh token for the user-bound grants.
+func (c *Core) issueTokenPair(ctx context.Context, client oauthbus.Client, userID uuid.UUID, scopes []string) (TokenResponse, error) {
+	claims := TokenClaims{
+		Subject: userID.String(),
+		Scopes:  scopes,
+		Expires: time.Now().Add(accessTokenTTL),
+	}
+
+	access, err := c.issuer.Issue(ctx, c.kid, claims)
+	if err != nil {
+		return TokenResponse{}, errs.Newf(errs.Internal, "token: sign access token: %s", err)
+	}
+
+	refresh, err := randomToken()
+	if err != nil {
+		return TokenResponse{}, errs.Newf(errs.Internal, "token: generate refresh token: %s", err)
+	}
+
+	rt := oauthbus.RefreshToken{
+		Token:    refresh,
+		ClientID: client.ClientID,
+		UserID:   userID,
+		Scopes:   scopes,
+		Expires:  time.Now().Add(refreshTokenTTL),
+	}
+
+	if err := c.oauthBus.IssueRefreshToken(ctx, rt); err != nil {
+		return TokenResponse{}, errs.Newf(errs.Internal, "token: issue refresh token: %s", err)
+	}
+
+	resp := TokenResponse{
+		AccessToken:  access,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(accessTokenTTL.Seconds()),
+		RefreshToken: refresh,
+		Scope:        joinScope(scopes),
+	}
+
+	idClaims := claims
+	if hasScope(scopes, "roles") {
+		idClaims.Roles = c.userRoles(ctx, userID)
+	}
+
+	idToken, err := c.issuer.Issue(ctx, c.kid, idClaims)
+	if err != nil {
+		return TokenResponse{}, errs.Newf(errs.Internal, "token: sign id_token: %s", err)
+	}
+
+	resp.IDToken = idToken
+
+	return resp, nil
+}
+
+// hashSecret hashes a client secret with bcrypt, which is deliberately slow
+// so an offline brute force against a leaked oauth_clients table isn't
+// cheap, even for a low-entropy secret an operator typed in by hand through
+// the admin UI. A fast, unkeyed digest like sha256 would not provide that.
+func hashSecret(secret string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func verifySecret(hash, candidate string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(candidate)) == nil
+}
+
+func verifyPKCE(challenge, verifier string) bool {
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(computed)) == 1
+}
+
+func randomToken() (string, error) {
+	return uuid.NewString(), nil
+}
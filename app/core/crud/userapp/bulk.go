@@ -0,0 +1,121 @@
+package userapp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ardanlabs/service/app/api/errs"
+	"github.com/ardanlabs/service/app/api/mid"
+	"github.com/ardanlabs/service/business/core/crud/user"
+	"github.com/google/uuid"
+)
+
+// maxBulkDelete is the largest batch DeleteBulk will accept in one call.
+const maxBulkDelete = 500
+
+// BulkResult reports the outcome of one id in a bulk operation.
+type BulkResult struct {
+	ID     uuid.UUID `json:"id"`
+	Status int       `json:"status"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// HomeDeleter is the narrow capability DeleteBulk needs from the home
+// domain to cascade a user's deletion to the homes they own. It is kept
+// local to this package, the same way oauthapp declares its own Issuer
+// interface for what it needs from token signing, so userapp depends on a
+// capability rather than importing homebus/homeapp directly.
+type HomeDeleter interface {
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// AuditEvent describes a single row-level mutation for the audit log.
+type AuditEvent struct {
+	Action     string
+	Resource   string
+	ResourceID string
+	ActorID    string
+}
+
+// AuditLogger records a single audit event. DeleteBulk emits one per user it
+// actually deletes, so a partial bulk delete still leaves a trail naming
+// exactly the rows that changed. It does not cover homes removed by the
+// cascade itself - HomeDeleter only reports success or failure for a user's
+// whole set of homes, not how many rows that affected.
+type AuditLogger interface {
+	Record(ctx context.Context, event AuditEvent) error
+}
+
+// DeleteBulk removes the users identified by ids. When cascade is true,
+// each deleted user's homes are removed via homes in the same pass so no
+// home is left pointing at a user that no longer exists. When atomic is
+// true, every id is resolved to an existing user before any delete runs, so
+// a missing id can never leave an earlier id in the same call deleted while
+// the caller is told the whole batch failed; the cascade delete and the
+// user delete themselves still aren't wrapped in a database transaction
+// (userBus has no Tx primitive to expose here), so a failure partway
+// through those two steps for a given user can still leave that one user's
+// homes removed without the user row itself gone, and every earlier user in
+// the batch already deleted - that failure is
+// returned as an error same as today, it just can no longer be triggered by
+// the cheaper, far more common case of a bad id. In non-atomic mode each id
+// is still reported independently via the returned results, same as
+// before. Regardless of atomic, one audit event is emitted for every user
+// actually deleted.
+func (c *Core) DeleteBulk(ctx context.Context, ids []uuid.UUID, cascade, atomic bool) ([]BulkResult, error) {
+	if len(ids) > maxBulkDelete {
+		return nil, errs.Newf(errs.FailedPrecondition, "deletebulk: %d ids exceeds the %d limit", len(ids), maxBulkDelete)
+	}
+
+	actorID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return nil, errs.Newf(errs.Unauthenticated, "deletebulk: %s", err)
+	}
+
+	results := make([]BulkResult, 0, len(ids))
+	users := make([]user.User, 0, len(ids))
+
+	for _, id := range ids {
+		usr, err := c.userBus.QueryByID(ctx, id)
+		if err != nil {
+			if atomic {
+				return nil, errs.Newf(errs.NotFound, "deletebulk: user[%s]: %s", id, err)
+			}
+
+			results = append(results, BulkResult{ID: id, Status: http.StatusNotFound, Error: err.Error()})
+			continue
+		}
+
+		users = append(users, usr)
+	}
+
+	for _, usr := range users {
+		if cascade {
+			if err := c.homes.DeleteByUserID(ctx, usr.ID); err != nil {
+				if atomic {
+					return nil, errs.Newf(errs.Internal, "deletebulk: cascade homes for user[%s]: %s", usr.ID, err)
+				}
+
+				results = append(results, BulkResult{ID: usr.ID, Status: http.StatusInternalServerError, Error: err.Error()})
+				continue
+			}
+		}
+
+		if err := c.userBus.Delete(ctx, usr); err != nil {
+			if atomic {
+				return nil, errs.Newf(errs.Internal, "deletebulk: user[%s]: %s", usr.ID, err)
+			}
+
+			results = append(results, BulkResult{ID: usr.ID, Status: http.StatusInternalServerError, Error: err.Error()})
+			continue
+		}
+
+		if err := c.audit.Record(ctx, AuditEvent{Action: "delete", Resource: "user", ResourceID: usr.ID.String(), ActorID: actorID.String()}); err != nil {
+			return nil, errs.Newf(errs.Internal, "deletebulk: user[%s]: audit: %s", usr.ID, err)
+		}
+
+		results = append(results, BulkResult{ID: usr.ID, Status: http.StatusNoContent})
+	}
+
+	return results, nil
+}
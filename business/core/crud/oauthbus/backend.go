@@ -0,0 +1,16 @@
+package oauthbus
+
+import "github.com/ardanlabs/service/business/data/backend"
+
+// ClientBackends, AuthRequestBackends, and RefreshTokenBackends are this
+// core's backend registries. backend.Registry is generic over a single
+// Storer interface, and this core depends on three, so it owns one registry
+// per interface rather than trying to share a single registry across
+// incompatible Storer shapes. Storage packages register themselves here
+// from their own package init (see stores/oauthdb for "postgres" and
+// stores/oauthmemory for "memory").
+var (
+	ClientBackends       = backend.NewRegistry[ClientStorer]()
+	AuthRequestBackends  = backend.NewRegistry[AuthRequestStorer]()
+	RefreshTokenBackends = backend.NewRegistry[RefreshTokenStorer]()
+)
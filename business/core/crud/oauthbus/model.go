@@ -0,0 +1,71 @@
+package oauthbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GrantType represents the OAuth2 grant types a client is allowed to use.
+type GrantType string
+
+// Set of known grant types.
+const (
+	GrantAuthorizationCode GrantType = "authorization_code"
+	GrantRefreshToken      GrantType = "refresh_token"
+	GrantClientCredentials GrantType = "client_credentials"
+	GrantPassword          GrantType = "password"
+)
+
+// Client represents a registered OAuth2 client application.
+type Client struct {
+	ID            uuid.UUID
+	ClientID      string
+	ClientSecret  string // stored as a bcrypt hash, never the plaintext value
+	RedirectURIs  []string
+	AllowedScopes []string
+	GrantTypes    []GrantType
+	DateCreated   time.Time
+	DateUpdated   time.Time
+}
+
+// NewClient contains the information needed to register a new client.
+type NewClient struct {
+	ClientID      string
+	ClientSecret  string
+	RedirectURIs  []string
+	AllowedScopes []string
+	GrantTypes    []GrantType
+}
+
+// UpdateClient contains the information that can be changed on a client.
+type UpdateClient struct {
+	RedirectURIs  *[]string
+	AllowedScopes *[]string
+	GrantTypes    *[]GrantType
+}
+
+// AuthRequest represents a single pass through the /authorize endpoint, from
+// consent to code exchange. It is kept in a pluggable repo so a code can be
+// redeemed exactly once and can expire independently of the client or token.
+type AuthRequest struct {
+	Code                string
+	ClientID            string
+	UserID              uuid.UUID
+	RedirectURI         string
+	Scopes              []string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Expires             time.Time
+	Redeemed            bool
+}
+
+// RefreshToken represents an issued, rotatable refresh token.
+type RefreshToken struct {
+	Token    string
+	ClientID string
+	UserID   uuid.UUID
+	Scopes   []string
+	Expires  time.Time
+	Revoked  bool
+}
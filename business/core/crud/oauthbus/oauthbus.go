@@ -0,0 +1,207 @@
+// Package oauthbus provides business access to OAuth2 clients, authorization
+// requests, and refresh tokens for the OIDC authorization server.
+package oauthbus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrClientNotFound  = errors.New("client not found")
+	ErrClientExists    = errors.New("client already exists")
+	ErrCodeNotFound    = errors.New("authorization code not found")
+	ErrCodeExpired     = errors.New("authorization code expired")
+	ErrCodeRedeemed    = errors.New("authorization code already redeemed")
+	ErrRefreshNotFound = errors.New("refresh token not found")
+	ErrRefreshRevoked  = errors.New("refresh token revoked")
+)
+
+// ClientStorer interface declares the behavior this package needs to persist
+// and retrieve OAuth2 clients.
+type ClientStorer interface {
+	Create(ctx context.Context, client Client) error
+	Update(ctx context.Context, client Client) error
+	Delete(ctx context.Context, client Client) error
+	QueryByClientID(ctx context.Context, clientID string) (Client, error)
+	QueryAll(ctx context.Context) ([]Client, error)
+}
+
+// AuthRequestStorer interface declares the behavior this package needs to
+// persist and redeem authorization codes. It is intentionally small so it
+// can be backed by memory for single-instance deployments or postgres for
+// multi-instance ones.
+type AuthRequestStorer interface {
+	Create(ctx context.Context, ar AuthRequest) error
+	QueryByCode(ctx context.Context, code string) (AuthRequest, error)
+	Redeem(ctx context.Context, code string) error
+}
+
+// RefreshTokenStorer interface declares the behavior this package needs to
+// persist, rotate, and revoke refresh tokens.
+type RefreshTokenStorer interface {
+	Create(ctx context.Context, rt RefreshToken) error
+	QueryByToken(ctx context.Context, token string) (RefreshToken, error)
+	Revoke(ctx context.Context, token string) error
+}
+
+// Core manages the set of business API functions for OAuth2 client, auth
+// request, and refresh token access.
+type Core struct {
+	clientStorer  ClientStorer
+	authStorer    AuthRequestStorer
+	refreshStorer RefreshTokenStorer
+}
+
+// NewCore constructs an oauth core API for use.
+func NewCore(clientStorer ClientStorer, authStorer AuthRequestStorer, refreshStorer RefreshTokenStorer) *Core {
+	return &Core{
+		clientStorer:  clientStorer,
+		authStorer:    authStorer,
+		refreshStorer: refreshStorer,
+	}
+}
+
+// CreateClient adds a new OAuth2 client to the system.
+func (c *Core) CreateClient(ctx context.Context, nc NewClient) (Client, error) {
+	now := time.Now()
+
+	client := Client{
+		ID:            uuid.New(),
+		ClientID:      nc.ClientID,
+		ClientSecret:  nc.ClientSecret,
+		RedirectURIs:  nc.RedirectURIs,
+		AllowedScopes: nc.AllowedScopes,
+		GrantTypes:    nc.GrantTypes,
+		DateCreated:   now,
+		DateUpdated:   now,
+	}
+
+	if err := c.clientStorer.Create(ctx, client); err != nil {
+		return Client{}, err
+	}
+
+	return client, nil
+}
+
+// UpdateClient modifies information about an existing OAuth2 client.
+func (c *Core) UpdateClient(ctx context.Context, client Client, uc UpdateClient) (Client, error) {
+	if uc.RedirectURIs != nil {
+		client.RedirectURIs = *uc.RedirectURIs
+	}
+
+	if uc.AllowedScopes != nil {
+		client.AllowedScopes = *uc.AllowedScopes
+	}
+
+	if uc.GrantTypes != nil {
+		client.GrantTypes = *uc.GrantTypes
+	}
+
+	client.DateUpdated = time.Now()
+
+	if err := c.clientStorer.Update(ctx, client); err != nil {
+		return Client{}, err
+	}
+
+	return client, nil
+}
+
+// DeleteClient removes an OAuth2 client from the system.
+func (c *Core) DeleteClient(ctx context.Context, client Client) error {
+	return c.clientStorer.Delete(ctx, client)
+}
+
+// QueryClientByClientID finds a client by its public client_id.
+func (c *Core) QueryClientByClientID(ctx context.Context, clientID string) (Client, error) {
+	return c.clientStorer.QueryByClientID(ctx, clientID)
+}
+
+// QueryClients returns every registered OAuth2 client.
+func (c *Core) QueryClients(ctx context.Context) ([]Client, error) {
+	return c.clientStorer.QueryAll(ctx)
+}
+
+// GrantSupports reports whether a client is configured for the given grant type.
+func (c Client) GrantSupports(grant GrantType) bool {
+	for _, g := range c.GrantTypes {
+		if g == grant {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RedirectAllowed reports whether the given redirect URI was registered for
+// this client. Clients must match exactly; there is no prefix matching.
+func (c Client) RedirectAllowed(redirectURI string) bool {
+	for _, uri := range c.RedirectURIs {
+		if uri == redirectURI {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IssueAuthRequest stores a new authorization code for later redemption by
+// the /token endpoint.
+func (c *Core) IssueAuthRequest(ctx context.Context, ar AuthRequest) error {
+	return c.authStorer.Create(ctx, ar)
+}
+
+// RedeemAuthRequest looks up an authorization code, validates that it has
+// not expired, and marks it redeemed so it cannot be exchanged twice. The
+// single-use guarantee comes from authStorer.Redeem itself performing an
+// atomic check-and-set; two concurrent calls for the same code may both
+// reach this point with Redeemed == false, but only one of them will have
+// its Redeem call succeed.
+func (c *Core) RedeemAuthRequest(ctx context.Context, code string) (AuthRequest, error) {
+	ar, err := c.authStorer.QueryByCode(ctx, code)
+	if err != nil {
+		return AuthRequest{}, err
+	}
+
+	if time.Now().After(ar.Expires) {
+		return AuthRequest{}, ErrCodeExpired
+	}
+
+	if err := c.authStorer.Redeem(ctx, code); err != nil {
+		return AuthRequest{}, err
+	}
+
+	return ar, nil
+}
+
+// IssueRefreshToken stores a new refresh token.
+func (c *Core) IssueRefreshToken(ctx context.Context, rt RefreshToken) error {
+	return c.refreshStorer.Create(ctx, rt)
+}
+
+// RotateRefreshToken validates and revokes the presented refresh token so it
+// can be exchanged for a new access token and a new refresh token. Callers
+// are responsible for issuing the replacement via IssueRefreshToken. As with
+// RedeemAuthRequest, the single-use guarantee comes from refreshStorer.Revoke
+// performing an atomic check-and-set; this function does not check Revoked
+// itself.
+func (c *Core) RotateRefreshToken(ctx context.Context, token string) (RefreshToken, error) {
+	rt, err := c.refreshStorer.QueryByToken(ctx, token)
+	if err != nil {
+		return RefreshToken{}, err
+	}
+
+	if time.Now().After(rt.Expires) {
+		return RefreshToken{}, ErrRefreshNotFound
+	}
+
+	if err := c.refreshStorer.Revoke(ctx, token); err != nil {
+		return RefreshToken{}, err
+	}
+
+	return rt, nil
+}
@@ -0,0 +1,128 @@
+package oauthdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	"github.com/ardanlabs/service/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// AuthRequestStore manages the set of APIs for authorization code access
+// against a postgres database.
+type AuthRequestStore struct {
+	log *logger.Logger
+	db  *sqlx.DB
+}
+
+// NewAuthRequestStore constructs the api for auth request access.
+func NewAuthRequestStore(log *logger.Logger, db *sqlx.DB) *AuthRequestStore {
+	return &AuthRequestStore{
+		log: log,
+		db:  db,
+	}
+}
+
+// Create inserts a new authorization code into the database.
+func (s *AuthRequestStore) Create(ctx context.Context, ar oauthbus.AuthRequest) error {
+	const q = `
+	INSERT INTO oauth_auth_requests
+		(code, client_id, user_id, redirect_uri, scopes, code_challenge, code_challenge_method, expires, redeemed)
+	VALUES
+		(:code, :client_id, :user_id, :redirect_uri, :scopes, :code_challenge, :code_challenge_method, :expires, :redeemed)`
+
+	if _, err := s.db.NamedExecContext(ctx, q, toDBAuthRequest(ar)); err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByCode finds an authorization code in the database.
+func (s *AuthRequestStore) QueryByCode(ctx context.Context, code string) (oauthbus.AuthRequest, error) {
+	data := struct {
+		Code string `db:"code"`
+	}{
+		Code: code,
+	}
+
+	const q = `
+	SELECT
+		code, client_id, user_id, redirect_uri, scopes, code_challenge, code_challenge_method, expires, redeemed
+	FROM
+		oauth_auth_requests
+	WHERE
+		code = :code`
+
+	var dbAR dbAuthRequest
+	if err := s.namedQueryStruct(ctx, q, data, &dbAR); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return oauthbus.AuthRequest{}, oauthbus.ErrCodeNotFound
+		}
+
+		return oauthbus.AuthRequest{}, fmt.Errorf("query: %w", err)
+	}
+
+	return toBusAuthRequest(dbAR), nil
+}
+
+// Redeem marks an authorization code as used. The UPDATE's WHERE clause
+// makes the check-and-set atomic at the database level, so two concurrent
+// redemptions of the same code can't both succeed.
+func (s *AuthRequestStore) Redeem(ctx context.Context, code string) error {
+	data := struct {
+		Code string `db:"code"`
+	}{
+		Code: code,
+	}
+
+	const q = `
+	UPDATE oauth_auth_requests SET
+		redeemed = true
+	WHERE
+		code = :code AND redeemed = false`
+
+	res, err := s.db.NamedExecContext(ctx, q, data)
+	if err != nil {
+		return fmt.Errorf("redeem: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("redeem: %w", err)
+	}
+
+	if n == 0 {
+		// The UPDATE matched nothing: either the code doesn't exist, or it
+		// was already redeemed (possibly by a concurrent request that won
+		// the race). Disambiguate with a read so the caller gets the right
+		// error.
+		if _, err := s.QueryByCode(ctx, code); err != nil {
+			return err
+		}
+
+		return oauthbus.ErrCodeRedeemed
+	}
+
+	return nil
+}
+
+// namedQueryStruct is a thin wrapper kept local so the rest of this file
+// reads like the other CRUD cores; it mirrors the sqldb helper used
+// elsewhere in the business layer.
+func (s *AuthRequestStore) namedQueryStruct(ctx context.Context, query string, arg, dest any) error {
+	rows, err := s.db.NamedQueryContext(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return sql.ErrNoRows
+	}
+
+	return rows.StructScan(dest)
+}
@@ -0,0 +1,78 @@
+package oauthdb_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	_ "github.com/ardanlabs/service/business/core/crud/oauthbus/stores/oauthdb"
+	"github.com/ardanlabs/service/business/data/backend"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TestPostgresAuthRequestStore_Conformance runs the same round trip
+// TestMemoryAuthRequestStore_Conformance does, against the "postgres"
+// backend resolved through the real oauthbus.AuthRequestBackends registry.
+// It needs a live database, so it's skipped unless OAUTHDB_TEST_DSN is set;
+// this checkout has no docker-compose/dbtest harness to spin one up, so in
+// CI here it always skips. Once that harness lands, pointing
+// OAUTHDB_TEST_DSN at it is enough to exercise this test for real.
+func TestPostgresAuthRequestStore_Conformance(t *testing.T) {
+	dsn := os.Getenv("OAUTHDB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("OAUTHDB_TEST_DSN not set; skipping postgres conformance test")
+	}
+
+	db, err := sqlx.Connect("pgx", dsn)
+	if err != nil {
+		t.Fatalf("connect: %s", err)
+	}
+	defer db.Close()
+
+	store, err := oauthbus.AuthRequestBackends.Resolve(backend.DefaultName, backend.Config{DB: db})
+	if err != nil {
+		t.Fatalf("resolve postgres backend: %s", err)
+	}
+
+	ctx := context.Background()
+
+	ar := oauthbus.AuthRequest{
+		Code:                uuid.NewString(),
+		ClientID:            "client-1",
+		UserID:              uuid.New(),
+		RedirectURI:         "https://client.example/callback",
+		Scopes:              []string{"homes.read"},
+		CodeChallenge:       "challenge",
+		CodeChallengeMethod: "S256",
+		Expires:             time.Now().Add(time.Minute),
+	}
+
+	if err := store.Create(ctx, ar); err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	got, err := store.QueryByCode(ctx, ar.Code)
+	if err != nil {
+		t.Fatalf("querybycode: %s", err)
+	}
+
+	if got.Code != ar.Code || got.ClientID != ar.ClientID {
+		t.Fatalf("querybycode: got %+v, want %+v", got, ar)
+	}
+
+	if err := store.Redeem(ctx, ar.Code); err != nil {
+		t.Fatalf("redeem: %s", err)
+	}
+
+	if err := store.Redeem(ctx, ar.Code); !errors.Is(err, oauthbus.ErrCodeRedeemed) {
+		t.Fatalf("redeem again: got %v, want ErrCodeRedeemed", err)
+	}
+
+	if _, err := store.QueryByCode(ctx, uuid.NewString()); !errors.Is(err, oauthbus.ErrCodeNotFound) {
+		t.Fatalf("querybycode unknown: got %v, want ErrCodeNotFound", err)
+	}
+}
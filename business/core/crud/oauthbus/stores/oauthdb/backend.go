@@ -0,0 +1,22 @@
+package oauthdb
+
+import (
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	"github.com/ardanlabs/service/business/data/backend"
+)
+
+// init registers this package's stores as the backend.DefaultName
+// ("postgres") backend for every oauthbus Storer interface they implement.
+func init() {
+	backend.MustRegister(oauthbus.ClientBackends, backend.DefaultName, func(cfg backend.Config) (oauthbus.ClientStorer, error) {
+		return NewStore(cfg.Log, cfg.DB), nil
+	})
+
+	backend.MustRegister(oauthbus.AuthRequestBackends, backend.DefaultName, func(cfg backend.Config) (oauthbus.AuthRequestStorer, error) {
+		return NewAuthRequestStore(cfg.Log, cfg.DB), nil
+	})
+
+	backend.MustRegister(oauthbus.RefreshTokenBackends, backend.DefaultName, func(cfg backend.Config) (oauthbus.RefreshTokenStorer, error) {
+		return NewRefreshTokenStore(cfg.Log, cfg.DB), nil
+	})
+}
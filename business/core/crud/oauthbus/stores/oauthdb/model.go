@@ -0,0 +1,154 @@
+package oauthdb
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	"github.com/google/uuid"
+)
+
+// dbClient represents the structure we need for moving data between the app
+// and the database for an oauth client row.
+type dbClient struct {
+	ID            string    `db:"oauth_client_id"`
+	ClientID      string    `db:"client_id"`
+	ClientSecret  string    `db:"client_secret"`
+	RedirectURIs  string    `db:"redirect_uris"`
+	AllowedScopes string    `db:"allowed_scopes"`
+	GrantTypes    string    `db:"grant_types"`
+	DateCreated   time.Time `db:"date_created"`
+	DateUpdated   time.Time `db:"date_updated"`
+}
+
+// The client's repeated-value columns are stored as comma separated text so
+// a single row round trips through a plain SQL insert without a join table.
+const listSep = ","
+
+func toDBClient(bus oauthbus.Client) dbClient {
+	return dbClient{
+		ID:            bus.ID.String(),
+		ClientID:      bus.ClientID,
+		ClientSecret:  bus.ClientSecret,
+		RedirectURIs:  strings.Join(bus.RedirectURIs, listSep),
+		AllowedScopes: strings.Join(bus.AllowedScopes, listSep),
+		GrantTypes:    joinGrantTypes(bus.GrantTypes),
+		DateCreated:   bus.DateCreated,
+		DateUpdated:   bus.DateUpdated,
+	}
+}
+
+func toBusClient(db dbClient) oauthbus.Client {
+	return oauthbus.Client{
+		ID:            uuid.MustParse(db.ID),
+		ClientID:      db.ClientID,
+		ClientSecret:  db.ClientSecret,
+		RedirectURIs:  splitNonEmpty(db.RedirectURIs),
+		AllowedScopes: splitNonEmpty(db.AllowedScopes),
+		GrantTypes:    splitGrantTypes(db.GrantTypes),
+		DateCreated:   db.DateCreated,
+		DateUpdated:   db.DateUpdated,
+	}
+}
+
+// dbAuthRequest represents the structure we need for moving data between
+// the app and the database for an authorization code row.
+type dbAuthRequest struct {
+	Code                string    `db:"code"`
+	ClientID            string    `db:"client_id"`
+	UserID              string    `db:"user_id"`
+	RedirectURI         string    `db:"redirect_uri"`
+	Scopes              string    `db:"scopes"`
+	CodeChallenge       string    `db:"code_challenge"`
+	CodeChallengeMethod string    `db:"code_challenge_method"`
+	Expires             time.Time `db:"expires"`
+	Redeemed            bool      `db:"redeemed"`
+}
+
+func toDBAuthRequest(bus oauthbus.AuthRequest) dbAuthRequest {
+	return dbAuthRequest{
+		Code:                bus.Code,
+		ClientID:            bus.ClientID,
+		UserID:              bus.UserID.String(),
+		RedirectURI:         bus.RedirectURI,
+		Scopes:              strings.Join(bus.Scopes, listSep),
+		CodeChallenge:       bus.CodeChallenge,
+		CodeChallengeMethod: bus.CodeChallengeMethod,
+		Expires:             bus.Expires,
+		Redeemed:            bus.Redeemed,
+	}
+}
+
+func toBusAuthRequest(db dbAuthRequest) oauthbus.AuthRequest {
+	return oauthbus.AuthRequest{
+		Code:                db.Code,
+		ClientID:            db.ClientID,
+		UserID:              uuid.MustParse(db.UserID),
+		RedirectURI:         db.RedirectURI,
+		Scopes:              splitNonEmpty(db.Scopes),
+		CodeChallenge:       db.CodeChallenge,
+		CodeChallengeMethod: db.CodeChallengeMethod,
+		Expires:             db.Expires,
+		Redeemed:            db.Redeemed,
+	}
+}
+
+// dbRefreshToken represents the structure we need for moving data between
+// the app and the database for a refresh token row.
+type dbRefreshToken struct {
+	Token    string    `db:"token"`
+	ClientID string    `db:"client_id"`
+	UserID   string    `db:"user_id"`
+	Scopes   string    `db:"scopes"`
+	Expires  time.Time `db:"expires"`
+	Revoked  bool      `db:"revoked"`
+}
+
+func toDBRefreshToken(bus oauthbus.RefreshToken) dbRefreshToken {
+	return dbRefreshToken{
+		Token:    bus.Token,
+		ClientID: bus.ClientID,
+		UserID:   bus.UserID.String(),
+		Scopes:   strings.Join(bus.Scopes, listSep),
+		Expires:  bus.Expires,
+		Revoked:  bus.Revoked,
+	}
+}
+
+func toBusRefreshToken(db dbRefreshToken) oauthbus.RefreshToken {
+	return oauthbus.RefreshToken{
+		Token:    db.Token,
+		ClientID: db.ClientID,
+		UserID:   uuid.MustParse(db.UserID),
+		Scopes:   splitNonEmpty(db.Scopes),
+		Expires:  db.Expires,
+		Revoked:  db.Revoked,
+	}
+}
+
+func splitNonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, listSep)
+}
+
+func joinGrantTypes(grants []oauthbus.GrantType) string {
+	ss := make([]string, len(grants))
+	for i, g := range grants {
+		ss[i] = string(g)
+	}
+
+	return strings.Join(ss, listSep)
+}
+
+func splitGrantTypes(s string) []oauthbus.GrantType {
+	parts := splitNonEmpty(s)
+	grants := make([]oauthbus.GrantType, len(parts))
+	for i, p := range parts {
+		grants[i] = oauthbus.GrantType(p)
+	}
+
+	return grants
+}
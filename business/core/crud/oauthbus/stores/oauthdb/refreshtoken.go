@@ -0,0 +1,128 @@
+package oauthdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	"github.com/ardanlabs/service/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// RefreshTokenStore manages the set of APIs for refresh token access
+// against a postgres database.
+type RefreshTokenStore struct {
+	log *logger.Logger
+	db  *sqlx.DB
+}
+
+// NewRefreshTokenStore constructs the api for refresh token access.
+func NewRefreshTokenStore(log *logger.Logger, db *sqlx.DB) *RefreshTokenStore {
+	return &RefreshTokenStore{
+		log: log,
+		db:  db,
+	}
+}
+
+// Create inserts a new refresh token into the database.
+func (s *RefreshTokenStore) Create(ctx context.Context, rt oauthbus.RefreshToken) error {
+	const q = `
+	INSERT INTO oauth_refresh_tokens
+		(token, client_id, user_id, scopes, expires, revoked)
+	VALUES
+		(:token, :client_id, :user_id, :scopes, :expires, :revoked)`
+
+	if _, err := s.db.NamedExecContext(ctx, q, toDBRefreshToken(rt)); err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByToken finds a refresh token in the database.
+func (s *RefreshTokenStore) QueryByToken(ctx context.Context, token string) (oauthbus.RefreshToken, error) {
+	data := struct {
+		Token string `db:"token"`
+	}{
+		Token: token,
+	}
+
+	const q = `
+	SELECT
+		token, client_id, user_id, scopes, expires, revoked
+	FROM
+		oauth_refresh_tokens
+	WHERE
+		token = :token`
+
+	var dbRT dbRefreshToken
+	if err := s.namedQueryStruct(ctx, q, data, &dbRT); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return oauthbus.RefreshToken{}, oauthbus.ErrRefreshNotFound
+		}
+
+		return oauthbus.RefreshToken{}, fmt.Errorf("query: %w", err)
+	}
+
+	return toBusRefreshToken(dbRT), nil
+}
+
+// Revoke marks a refresh token as used. The UPDATE's WHERE clause makes the
+// check-and-set atomic at the database level, so two concurrent rotations
+// of the same token can't both succeed.
+func (s *RefreshTokenStore) Revoke(ctx context.Context, token string) error {
+	data := struct {
+		Token string `db:"token"`
+	}{
+		Token: token,
+	}
+
+	const q = `
+	UPDATE oauth_refresh_tokens SET
+		revoked = true
+	WHERE
+		token = :token AND revoked = false`
+
+	res, err := s.db.NamedExecContext(ctx, q, data)
+	if err != nil {
+		return fmt.Errorf("revoke: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("revoke: %w", err)
+	}
+
+	if n == 0 {
+		// The UPDATE matched nothing: either the token doesn't exist, or it
+		// was already revoked (possibly by a concurrent request that won
+		// the race). Disambiguate with a read so the caller gets the right
+		// error.
+		if _, err := s.QueryByToken(ctx, token); err != nil {
+			return err
+		}
+
+		return oauthbus.ErrRefreshRevoked
+	}
+
+	return nil
+}
+
+// namedQueryStruct is a thin wrapper kept local so the rest of this file
+// reads like the other CRUD cores; it mirrors the sqldb helper used
+// elsewhere in the business layer.
+func (s *RefreshTokenStore) namedQueryStruct(ctx context.Context, query string, arg, dest any) error {
+	rows, err := s.db.NamedQueryContext(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return sql.ErrNoRows
+	}
+
+	return rows.StructScan(dest)
+}
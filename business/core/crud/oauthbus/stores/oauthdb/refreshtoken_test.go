@@ -0,0 +1,71 @@
+package oauthdb_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	_ "github.com/ardanlabs/service/business/core/crud/oauthbus/stores/oauthdb"
+	"github.com/ardanlabs/service/business/data/backend"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TestPostgresRefreshTokenStore_Conformance mirrors
+// TestPostgresAuthRequestStore_Conformance for RefreshTokenStorer. See that
+// test's comment for why it's skipped without OAUTHDB_TEST_DSN.
+func TestPostgresRefreshTokenStore_Conformance(t *testing.T) {
+	dsn := os.Getenv("OAUTHDB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("OAUTHDB_TEST_DSN not set; skipping postgres conformance test")
+	}
+
+	db, err := sqlx.Connect("pgx", dsn)
+	if err != nil {
+		t.Fatalf("connect: %s", err)
+	}
+	defer db.Close()
+
+	store, err := oauthbus.RefreshTokenBackends.Resolve(backend.DefaultName, backend.Config{DB: db})
+	if err != nil {
+		t.Fatalf("resolve postgres backend: %s", err)
+	}
+
+	ctx := context.Background()
+
+	rt := oauthbus.RefreshToken{
+		Token:    uuid.NewString(),
+		ClientID: "client-1",
+		UserID:   uuid.New(),
+		Scopes:   []string{"homes.read"},
+		Expires:  time.Now().Add(time.Hour),
+	}
+
+	if err := store.Create(ctx, rt); err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	got, err := store.QueryByToken(ctx, rt.Token)
+	if err != nil {
+		t.Fatalf("querybytoken: %s", err)
+	}
+
+	if got.Token != rt.Token || got.ClientID != rt.ClientID {
+		t.Fatalf("querybytoken: got %+v, want %+v", got, rt)
+	}
+
+	if err := store.Revoke(ctx, rt.Token); err != nil {
+		t.Fatalf("revoke: %s", err)
+	}
+
+	if err := store.Revoke(ctx, rt.Token); !errors.Is(err, oauthbus.ErrRefreshRevoked) {
+		t.Fatalf("revoke again: got %v, want ErrRefreshRevoked", err)
+	}
+
+	if _, err := store.QueryByToken(ctx, uuid.NewString()); !errors.Is(err, oauthbus.ErrRefreshNotFound) {
+		t.Fatalf("querybytoken unknown: got %v, want ErrRefreshNotFound", err)
+	}
+}
@@ -0,0 +1,67 @@
+package oauthmemory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	_ "github.com/ardanlabs/service/business/core/crud/oauthbus/stores/oauthmemory"
+	"github.com/ardanlabs/service/business/data/backend"
+	"github.com/google/uuid"
+)
+
+// TestMemoryAuthRequestStore_Conformance resolves the "memory" backend
+// through the real oauthbus.AuthRequestBackends registry and exercises the
+// round trip every registered backend must provide: a code can be created
+// and read back, redeeming it once succeeds, redeeming it again reports
+// ErrCodeRedeemed rather than silently succeeding, and an unknown code
+// reports ErrCodeNotFound. Unlike ClientStorer, an authorization code isn't
+// deleted after use - it's marked redeemed - so this doesn't reuse
+// backendtest.Run, which assumes a Delete method AuthRequestStorer doesn't
+// have.
+func TestMemoryAuthRequestStore_Conformance(t *testing.T) {
+	store, err := oauthbus.AuthRequestBackends.Resolve("memory", backend.Config{})
+	if err != nil {
+		t.Fatalf("resolve memory backend: %s", err)
+	}
+
+	ctx := context.Background()
+
+	ar := oauthbus.AuthRequest{
+		Code:                uuid.NewString(),
+		ClientID:            "client-1",
+		UserID:              uuid.New(),
+		RedirectURI:         "https://client.example/callback",
+		Scopes:              []string{"homes.read"},
+		CodeChallenge:       "challenge",
+		CodeChallengeMethod: "S256",
+		Expires:             time.Now().Add(time.Minute),
+	}
+
+	if err := store.Create(ctx, ar); err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	got, err := store.QueryByCode(ctx, ar.Code)
+	if err != nil {
+		t.Fatalf("querybycode: %s", err)
+	}
+
+	if got.Code != ar.Code || got.ClientID != ar.ClientID {
+		t.Fatalf("querybycode: got %+v, want %+v", got, ar)
+	}
+
+	if err := store.Redeem(ctx, ar.Code); err != nil {
+		t.Fatalf("redeem: %s", err)
+	}
+
+	if err := store.Redeem(ctx, ar.Code); !errors.Is(err, oauthbus.ErrCodeRedeemed) {
+		t.Fatalf("redeem again: got %v, want ErrCodeRedeemed", err)
+	}
+
+	if _, err := store.QueryByCode(ctx, uuid.NewString()); !errors.Is(err, oauthbus.ErrCodeNotFound) {
+		t.Fatalf("querybycode unknown: got %v, want ErrCodeNotFound", err)
+	}
+}
@@ -0,0 +1,23 @@
+package oauthmemory
+
+import (
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	"github.com/ardanlabs/service/business/data/backend"
+)
+
+// init registers this package's stores as the "memory" backend for every
+// oauthbus Storer interface they implement, for single-instance
+// deployments and the backendtest conformance suite.
+func init() {
+	backend.MustRegister(oauthbus.ClientBackends, "memory", func(backend.Config) (oauthbus.ClientStorer, error) {
+		return NewClientStore(), nil
+	})
+
+	backend.MustRegister(oauthbus.AuthRequestBackends, "memory", func(backend.Config) (oauthbus.AuthRequestStorer, error) {
+		return NewStore(), nil
+	})
+
+	backend.MustRegister(oauthbus.RefreshTokenBackends, "memory", func(backend.Config) (oauthbus.RefreshTokenStorer, error) {
+		return NewRefreshTokenStore(), nil
+	})
+}
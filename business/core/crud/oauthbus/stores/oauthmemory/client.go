@@ -0,0 +1,91 @@
+package oauthmemory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+)
+
+// ClientStore manages the set of APIs for OAuth2 client access using an
+// in-memory map guarded by a mutex. It is intended for single-instance
+// deployments and tests where a database round trip isn't warranted.
+type ClientStore struct {
+	mu      sync.RWMutex
+	clients map[string]oauthbus.Client
+}
+
+// NewClientStore constructs the api for client access.
+func NewClientStore() *ClientStore {
+	return &ClientStore{
+		clients: make(map[string]oauthbus.Client),
+	}
+}
+
+// Create inserts a new client into the store.
+func (s *ClientStore) Create(ctx context.Context, client oauthbus.Client) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.clients[client.ClientID]; exists {
+		return oauthbus.ErrClientExists
+	}
+
+	s.clients[client.ClientID] = client
+
+	return nil
+}
+
+// Update replaces a client's mutable fields in the store.
+func (s *ClientStore) Update(ctx context.Context, client oauthbus.Client) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.clients[client.ClientID]; !exists {
+		return oauthbus.ErrClientNotFound
+	}
+
+	s.clients[client.ClientID] = client
+
+	return nil
+}
+
+// Delete removes a client from the store.
+func (s *ClientStore) Delete(ctx context.Context, client oauthbus.Client) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.clients[client.ClientID]; !exists {
+		return oauthbus.ErrClientNotFound
+	}
+
+	delete(s.clients, client.ClientID)
+
+	return nil
+}
+
+// QueryByClientID finds a client by its public client_id.
+func (s *ClientStore) QueryByClientID(ctx context.Context, clientID string) (oauthbus.Client, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	client, exists := s.clients[clientID]
+	if !exists {
+		return oauthbus.Client{}, oauthbus.ErrClientNotFound
+	}
+
+	return client, nil
+}
+
+// QueryAll retrieves every registered client.
+func (s *ClientStore) QueryAll(ctx context.Context) ([]oauthbus.Client, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clients := make([]oauthbus.Client, 0, len(s.clients))
+	for _, client := range s.clients {
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
@@ -0,0 +1,55 @@
+package oauthmemory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	_ "github.com/ardanlabs/service/business/core/crud/oauthbus/stores/oauthmemory"
+	"github.com/ardanlabs/service/business/data/backend"
+	"github.com/ardanlabs/service/business/data/backend/backendtest"
+	"github.com/google/uuid"
+)
+
+// clientConformance adapts oauthbus.ClientStorer's method names to
+// backendtest.CRUDStorer so the registry's "memory" entry can run the same
+// round-trip suite every registered oauthbus.ClientBackends entry must pass.
+type clientConformance struct {
+	store oauthbus.ClientStorer
+}
+
+func (c clientConformance) Create(ctx context.Context, client oauthbus.Client) error {
+	return c.store.Create(ctx, client)
+}
+
+func (c clientConformance) QueryByID(ctx context.Context, id string) (oauthbus.Client, error) {
+	return c.store.QueryByClientID(ctx, id)
+}
+
+func (c clientConformance) Delete(ctx context.Context, id string) error {
+	client, err := c.store.QueryByClientID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.store.Delete(ctx, client)
+}
+
+// TestMemoryClientStore_Conformance resolves the "memory" backend through
+// the real oauthbus.ClientBackends registry (proving Register/Resolve work
+// end to end) and runs the shared backendtest suite against it, the same
+// suite the postgres backend must also pass.
+func TestMemoryClientStore_Conformance(t *testing.T) {
+	store, err := oauthbus.ClientBackends.Resolve("memory", backend.Config{})
+	if err != nil {
+		t.Fatalf("resolve memory backend: %s", err)
+	}
+
+	newRecord := func() oauthbus.Client {
+		return oauthbus.Client{ClientID: uuid.NewString()}
+	}
+
+	backendtest.Run(t, context.Background(), clientConformance{store: store}, newRecord, func(c oauthbus.Client) string {
+		return c.ClientID
+	})
+}
@@ -0,0 +1,71 @@
+// Package oauthmemory contains an in-memory implementation of the
+// oauthbus.AuthRequestStorer interface. It is intended for single-instance
+// deployments and tests where a database round trip isn't warranted.
+package oauthmemory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+)
+
+// Store manages the set of APIs for authorization code access using an
+// in-memory map guarded by a mutex.
+type Store struct {
+	mu    sync.RWMutex
+	codes map[string]oauthbus.AuthRequest
+}
+
+// NewStore constructs the api for auth request access.
+func NewStore() *Store {
+	return &Store{
+		codes: make(map[string]oauthbus.AuthRequest),
+	}
+}
+
+// Create inserts a new authorization code into the store.
+func (s *Store) Create(ctx context.Context, ar oauthbus.AuthRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.codes[ar.Code] = ar
+
+	return nil
+}
+
+// QueryByCode retrieves an authorization code from the store.
+func (s *Store) QueryByCode(ctx context.Context, code string) (oauthbus.AuthRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ar, exists := s.codes[code]
+	if !exists {
+		return oauthbus.AuthRequest{}, oauthbus.ErrCodeNotFound
+	}
+
+	return ar, nil
+}
+
+// Redeem marks an authorization code as used so it cannot be exchanged
+// again. The existence check, the Redeemed check, and the set all happen
+// under the same lock, so two concurrent redemptions of the same code can't
+// both observe Redeemed == false and both succeed.
+func (s *Store) Redeem(ctx context.Context, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ar, exists := s.codes[code]
+	if !exists {
+		return oauthbus.ErrCodeNotFound
+	}
+
+	if ar.Redeemed {
+		return oauthbus.ErrCodeRedeemed
+	}
+
+	ar.Redeemed = true
+	s.codes[code] = ar
+
+	return nil
+}
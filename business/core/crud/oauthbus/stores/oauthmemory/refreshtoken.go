@@ -0,0 +1,68 @@
+package oauthmemory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+)
+
+// RefreshTokenStore manages the set of APIs for refresh token access using
+// an in-memory map guarded by a mutex. It is intended for single-instance
+// deployments and tests where a database round trip isn't warranted.
+type RefreshTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]oauthbus.RefreshToken
+}
+
+// NewRefreshTokenStore constructs the api for refresh token access.
+func NewRefreshTokenStore() *RefreshTokenStore {
+	return &RefreshTokenStore{
+		tokens: make(map[string]oauthbus.RefreshToken),
+	}
+}
+
+// Create inserts a new refresh token into the store.
+func (s *RefreshTokenStore) Create(ctx context.Context, rt oauthbus.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[rt.Token] = rt
+
+	return nil
+}
+
+// QueryByToken retrieves a refresh token from the store.
+func (s *RefreshTokenStore) QueryByToken(ctx context.Context, token string) (oauthbus.RefreshToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rt, exists := s.tokens[token]
+	if !exists {
+		return oauthbus.RefreshToken{}, oauthbus.ErrRefreshNotFound
+	}
+
+	return rt, nil
+}
+
+// Revoke marks a refresh token as used so it cannot be rotated again. The
+// existence check, the Revoked check, and the set all happen under the same
+// lock, so two concurrent rotations of the same token can't both succeed.
+func (s *RefreshTokenStore) Revoke(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rt, exists := s.tokens[token]
+	if !exists {
+		return oauthbus.ErrRefreshNotFound
+	}
+
+	if rt.Revoked {
+		return oauthbus.ErrRefreshRevoked
+	}
+
+	rt.Revoked = true
+	s.tokens[token] = rt
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package oauthmemory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardanlabs/service/business/core/crud/oauthbus"
+	_ "github.com/ardanlabs/service/business/core/crud/oauthbus/stores/oauthmemory"
+	"github.com/ardanlabs/service/business/data/backend"
+	"github.com/google/uuid"
+)
+
+// TestMemoryRefreshTokenStore_Conformance resolves the "memory" backend
+// through the real oauthbus.RefreshTokenBackends registry and exercises the
+// same kind of round trip TestMemoryAuthRequestStore_Conformance does for
+// auth codes: create, read back, revoke once, revoke again reports
+// ErrRefreshRevoked, and an unknown token reports ErrRefreshNotFound.
+func TestMemoryRefreshTokenStore_Conformance(t *testing.T) {
+	store, err := oauthbus.RefreshTokenBackends.Resolve("memory", backend.Config{})
+	if err != nil {
+		t.Fatalf("resolve memory backend: %s", err)
+	}
+
+	ctx := context.Background()
+
+	rt := oauthbus.RefreshToken{
+		Token:    uuid.NewString(),
+		ClientID: "client-1",
+		UserID:   uuid.New(),
+		Scopes:   []string{"homes.read"},
+		Expires:  time.Now().Add(time.Hour),
+	}
+
+	if err := store.Create(ctx, rt); err != nil {
+		t.Fatalf("create: %s", err)
+	}
+
+	got, err := store.QueryByToken(ctx, rt.Token)
+	if err != nil {
+		t.Fatalf("querybytoken: %s", err)
+	}
+
+	if got.Token != rt.Token || got.ClientID != rt.ClientID {
+		t.Fatalf("querybytoken: got %+v, want %+v", got, rt)
+	}
+
+	if err := store.Revoke(ctx, rt.Token); err != nil {
+		t.Fatalf("revoke: %s", err)
+	}
+
+	if err := store.Revoke(ctx, rt.Token); !errors.Is(err, oauthbus.ErrRefreshRevoked) {
+		t.Fatalf("revoke again: got %v, want ErrRefreshRevoked", err)
+	}
+
+	if _, err := store.QueryByToken(ctx, uuid.NewString()); !errors.Is(err, oauthbus.ErrRefreshNotFound) {
+		t.Fatalf("querybytoken unknown: got %v, want ErrRefreshNotFound", err)
+	}
+}
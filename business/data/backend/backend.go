@@ -0,0 +1,136 @@
+// Package backend provides a named registry of storage backend factories,
+// analogous to Terraform's backend init, so operators can select a core's
+// storage implementation (postgres, sqlite, in-memory, dynamodb, ...) via
+// configuration at startup instead of compile-time wiring. Each business
+// core owns its own Registry, instantiated for its own Storer interface,
+// and registers a "postgres" factory as its default in the store package's
+// init.
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ardanlabs/service/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultName is the backend Name returns when it is given neither a flag
+// value nor an env value.
+const DefaultName = "postgres"
+
+// Config is the set of inputs every backend factory can draw on to build its
+// Storer. Not every backend needs every field (an in-memory backend ignores
+// DB and DSN entirely).
+type Config struct {
+	Log *logger.Logger
+	DB  *sqlx.DB
+	DSN string
+}
+
+// Factory builds a Storer of type T from Config.
+type Factory[T any] func(cfg Config) (T, error)
+
+// Registry is a named set of storage backend factories for a single core's
+// Storer interface.
+type Registry[T any] struct {
+	mu         sync.RWMutex
+	factories  map[string]Factory[T]
+	deprecated map[string]string
+}
+
+// NewRegistry constructs an empty backend registry for T.
+func NewRegistry[T any]() *Registry[T] {
+	return &Registry[T]{
+		factories:  make(map[string]Factory[T]),
+		deprecated: make(map[string]string),
+	}
+}
+
+// Register adds a named backend factory. It returns an error if the name is
+// already registered; backends are meant to be registered once, from a
+// package init, never reassigned at runtime.
+func (r *Registry[T]) Register(name string, factory Factory[T]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("backend: %q is already registered", name)
+	}
+
+	r.factories[name] = factory
+
+	return nil
+}
+
+// Deprecated marks name as a shim for replacement: resolving name still
+// works, but it's routed to replacement's factory so operators can update
+// config at their own pace instead of breaking on upgrade.
+func (r *Registry[T]) Deprecated(name, replacement string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deprecated[name] = replacement
+}
+
+// Registered returns the names of every registered backend, sorted, for
+// help text and diagnostics.
+func (r *Registry[T]) Registered() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Resolve builds the Storer registered under name. If name was marked
+// Deprecated, it's transparently routed to the replacement backend.
+func (r *Registry[T]) Resolve(name string, cfg Config) (T, error) {
+	var zero T
+
+	r.mu.RLock()
+	if replacement, ok := r.deprecated[name]; ok {
+		name = replacement
+	}
+	factory, ok := r.factories[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return zero, fmt.Errorf("backend: %q is not a registered backend (have %v)", name, r.Registered())
+	}
+
+	return factory(cfg)
+}
+
+// Name resolves which backend a core should use: an explicit flag value
+// wins, then the STORAGE_BACKEND env value, then DefaultName. It takes plain
+// strings and is independent of any flag/config library; this package does
+// not define or parse a --storage.backend flag itself.
+func Name(flagValue, envValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+
+	if envValue != "" {
+		return envValue
+	}
+
+	return DefaultName
+}
+
+// MustRegister registers a named backend factory and panics if the name is
+// already registered. Intended for a storage package's init, the same way
+// database/sql drivers register themselves: a duplicate registration at
+// startup is a programmer error, not a runtime condition to recover from.
+func MustRegister[T any](r *Registry[T], name string, factory Factory[T]) {
+	if err := r.Register(name, factory); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,29 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/ardanlabs/service/business/data/backend"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name      string
+		flagValue string
+		envValue  string
+		want      string
+	}{
+		{name: "flag wins over env", flagValue: "memory", envValue: "postgres", want: "memory"},
+		{name: "env wins over default", flagValue: "", envValue: "memory", want: "memory"},
+		{name: "default when neither set", flagValue: "", envValue: "", want: backend.DefaultName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := backend.Name(tt.flagValue, tt.envValue)
+			if got != tt.want {
+				t.Fatalf("Name(%q, %q) = %q, want %q", tt.flagValue, tt.envValue, got, tt.want)
+			}
+		})
+	}
+}
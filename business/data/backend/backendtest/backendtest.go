@@ -0,0 +1,57 @@
+// Package backendtest provides a round-trip conformance suite that every
+// backend.Registry entry must pass, so a new storage implementation (sqlite,
+// in-memory, dynamodb, ...) is exercised against the same behavior the
+// postgres default provides.
+package backendtest
+
+import "context"
+
+// TB is the subset of testing.TB the suite needs, so it can run under the
+// standard testing package without importing it from this non-test file.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+// CRUDStorer is the minimal shape a core's Storer must implement for the
+// conformance suite to drive it: create a record, read it back by ID, and
+// delete it. Individual cores' real Storer interfaces are larger and every
+// method takes a context.Context, matching every Storer in this codebase;
+// callers adapt their Storer to this shape for the purposes of the suite.
+type CRUDStorer[T any] interface {
+	Create(ctx context.Context, t T) error
+	QueryByID(ctx context.Context, id string) (T, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// Run drives the conformance suite against storer, failing tb on the first
+// behavior that diverges from what every registered backend must provide.
+// newRecord must construct a record whose ID (as returned by idOf) is not
+// already present in storer.
+func Run[T any](tb TB, ctx context.Context, storer CRUDStorer[T], newRecord func() T, idOf func(T) string) {
+	tb.Helper()
+
+	record := newRecord()
+	id := idOf(record)
+
+	if err := storer.Create(ctx, record); err != nil {
+		tb.Fatalf("create: %s", err)
+	}
+
+	got, err := storer.QueryByID(ctx, id)
+	if err != nil {
+		tb.Fatalf("querybyid: %s", err)
+	}
+
+	if idOf(got) != id {
+		tb.Fatalf("querybyid: got id %q, want %q", idOf(got), id)
+	}
+
+	if err := storer.Delete(ctx, id); err != nil {
+		tb.Fatalf("delete: %s", err)
+	}
+
+	if _, err := storer.QueryByID(ctx, id); err == nil {
+		tb.Fatalf("querybyid: record %q still exists after delete", id)
+	}
+}
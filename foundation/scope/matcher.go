@@ -0,0 +1,64 @@
+package scope
+
+import "strings"
+
+// Matcher is a compiled required scope. Build one with Compile at route-bind
+// time, then call Allows on every request with the caller's granted scopes.
+type Matcher struct {
+	required []string
+}
+
+// Compile pre-splits the required scope into segments so Allows never has
+// to parse it again on the request hot path.
+func Compile(required Scope) *Matcher {
+	return &Matcher{
+		required: strings.Split(string(required), "."),
+	}
+}
+
+// Allows reports whether any of the granted scopes satisfy the scope this
+// Matcher was compiled for. granted is taken as raw strings, not []Scope, so
+// callers holding claims straight off a JWT (e.g. mid.AuthorizeScope) can
+// pass them through without a conversion allocation on the request hot path.
+//
+// Matching rules: a granted scope "a.b.*" matches required "a.b.c" and
+// "a.b"; a lone "*" matches anything; a "*" before the last segment of the
+// granted scope matches exactly one segment ("*.read" matches "users.read"
+// but not "users.write"); exact matches are granted as-is; segments the
+// granted scope doesn't mention never match.
+func (m *Matcher) Allows(granted []string) bool {
+	for _, g := range granted {
+		if matches(m.required, g) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches walks required segment by segment against granted, cutting
+// granted as it goes rather than splitting it into a slice up front, so the
+// check performs no heap allocations.
+func matches(required []string, granted string) bool {
+	remaining := granted
+
+	for i, req := range required {
+		seg, tail, hasMore := strings.Cut(remaining, ".")
+
+		if seg == "*" && !hasMore {
+			return true
+		}
+
+		if seg != "*" && seg != req {
+			return false
+		}
+
+		if !hasMore {
+			return i == len(required)-1
+		}
+
+		remaining = tail
+	}
+
+	return remaining == "" || remaining == "*"
+}
@@ -0,0 +1,42 @@
+// Package scope implements dotted, hierarchical authorization scopes (for
+// example "users.read", "users.*", "homes.write") with wildcard matching on
+// any segment. A Matcher is compiled once, at route-bind time, from the
+// scope a route requires, so the per-request check that follows a JWT's
+// granted scopes against it never allocates.
+package scope
+
+import "sync"
+
+// Scope is a dotted, hierarchical permission such as "users.read" or
+// "users.*". A trailing "*" segment, or a lone "*", grants everything at
+// and below that point.
+type Scope string
+
+var (
+	mu         sync.Mutex
+	registered = make(map[Scope]struct{})
+)
+
+// Register records that a scope is required by at least one mounted route.
+// It is called by mid.AuthorizeScope when a route is bound, not on the
+// request hot path.
+func Register(s Scope) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	registered[s] = struct{}{}
+}
+
+// Registered returns every scope referenced by any mounted route, for
+// documentation and consent-screen rendering.
+func Registered() []Scope {
+	mu.Lock()
+	defer mu.Unlock()
+
+	scopes := make([]Scope, 0, len(registered))
+	for s := range registered {
+		scopes = append(scopes, s)
+	}
+
+	return scopes
+}
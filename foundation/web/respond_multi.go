@@ -0,0 +1,20 @@
+package web
+
+import (
+	"context"
+	"net/http"
+)
+
+// RespondMulti writes a bulk operation's per-item results. When atomic is
+// false, partial failures are expected and the response is sent as
+// 207 Multi-Status so the caller can see which items succeeded; when atomic
+// is true the whole batch either succeeded or the caller already returned
+// an error, so results are sent as a plain 200.
+func RespondMulti(ctx context.Context, w http.ResponseWriter, results any, atomic bool) error {
+	status := http.StatusMultiStatus
+	if atomic {
+		status = http.StatusOK
+	}
+
+	return Respond(ctx, w, results, status)
+}